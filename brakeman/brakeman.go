@@ -2,21 +2,162 @@ package brakeman
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
+	"regexp"
+	"time"
 )
 
 type Report struct {
+	ScanInfo ScanInfo  `json:"scan_info"`
 	Warnings []Warning `json:"warnings"`
+	Errors   []Error   `json:"errors"`
+	// Obsolete lists the entries of the ignore file that no longer match any warning.
+	Obsolete []Obsolete `json:"obsolete"`
+}
+
+// Obsolete is the fingerprint of an ignore file entry that no longer matches
+// any warning. Brakeman reports it as a plain string.
+type Obsolete struct {
+	Fingerprint string
+	// AppRoot is the directory the report was rebased onto, set by Report.Rebase.
+	AppRoot string
+}
+
+func (o *Obsolete) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &o.Fingerprint)
+}
+
+func (o Obsolete) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.Fingerprint)
+}
+
+// Error is a problem Brakeman ran into while scanning, such as a file it could not parse.
+// Location is the first line of the backtrace, usually inside Brakeman or a gem.
+type Error struct {
+	Error    string `json:"error"`
+	Location string `json:"location"`
+	// AppRoot is the directory the report was rebased onto, set by Report.Rebase.
+	AppRoot string `json:"-"`
+}
+
+// errorFilePatterns match the messages Brakeman uses for files it failed to process.
+var errorFilePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(?:could not parse|while processing)\s+(\S+)`),
+	regexp.MustCompile(`\bParsing\s+(\S+)\s+took too long`),
+}
+
+// File returns the application file the error is about, prefixed with AppRoot,
+// or an empty string when the error does not name one.
+func (e Error) File() string {
+	for _, text := range []string{e.Error, e.Location} {
+		for _, pattern := range errorFilePatterns {
+			if match := pattern.FindStringSubmatch(text); match != nil {
+				return rebase(e.AppRoot, match[1])
+			}
+		}
+	}
+	return ""
+}
+
+// ScanInfo describes the Brakeman run that produced a report.
+// StartTime and EndTime are kept as Brakeman formats them, e.g. "2024-01-15 10:00:00 +0000".
+type ScanInfo struct {
+	AppPath             string   `json:"app_path"`
+	RailsVersion        string   `json:"rails_version"`
+	RubyVersion         string   `json:"ruby_version"`
+	BrakemanVersion     string   `json:"brakeman_version"`
+	ChecksPerformed     []string `json:"checks_performed"`
+	StartTime           string   `json:"start_time"`
+	EndTime             string   `json:"end_time"`
+	Duration            float64  `json:"duration"`
+	NumberOfControllers int      `json:"number_of_controllers"`
+	NumberOfModels      int      `json:"number_of_models"`
+	NumberOfTemplates   int      `json:"number_of_templates"`
+	SecurityWarnings    int      `json:"security_warnings"`
 }
 
 type Warning struct {
-	WarningType string `json:"warning_type"`
-	Message     string `json:"message"`
-	File        string `json:"file"`
-	Line        int    `json:"line"`
-	Confidence  string `json:"confidence"`
-	Code        string `json:"code,omitempty"`
-	Fingerprint string `json:"fingerprint"`
+	WarningType string            `json:"warning_type"`
+	WarningCode *int              `json:"warning_code,omitempty"`
+	CheckName   string            `json:"check_name"`
+	Message     string            `json:"message"`
+	File        string            `json:"file"`
+	Line        int               `json:"line"`
+	Link        string            `json:"link,omitempty"`
+	Confidence  Confidence        `json:"confidence"`
+	Code        string            `json:"code,omitempty"`
+	Location    *Location         `json:"location,omitempty"`
+	UserInput   string            `json:"user_input,omitempty"`
+	RenderPath  []RenderPathEntry `json:"render_path,omitempty"`
+	CWEID       []int             `json:"cwe_id,omitempty"`
+	Fingerprint string            `json:"fingerprint"`
+	// AppRoot is the directory the report was rebased onto, set by Report.Rebase.
+	// Brakeman fingerprints are only unique within one application.
+	AppRoot string `json:"-"`
+}
+
+// timeLayout is the layout Ruby's Time#to_s uses for the scan timestamps.
+const timeLayout = "2006-01-02 15:04:05 -0700"
+
+// StartedAt parses StartTime.
+func (s ScanInfo) StartedAt() (time.Time, error) {
+	return time.Parse(timeLayout, s.StartTime)
+}
+
+// EndedAt parses EndTime.
+func (s ScanInfo) EndedAt() (time.Time, error) {
+	return time.Parse(timeLayout, s.EndTime)
+}
+
+// Location describes where in the application a warning was raised.
+// Type is one of "method", "template" or "model"; the other fields are set accordingly.
+type Location struct {
+	Type     string `json:"type"`
+	Class    string `json:"class,omitempty"`
+	Method   string `json:"method,omitempty"`
+	Template string `json:"template,omitempty"`
+}
+
+// RenderPathEntry is one step of the chain that led to a template being rendered.
+type RenderPathEntry struct {
+	Type     string    `json:"type"`
+	Class    string    `json:"class,omitempty"`
+	Method   string    `json:"method,omitempty"`
+	Name     string    `json:"name,omitempty"`
+	Line     int       `json:"line"`
+	File     string    `json:"file"`
+	Rendered *Rendered `json:"rendered,omitempty"`
+}
+
+type Rendered struct {
+	Name string `json:"name"`
+	File string `json:"file"`
+}
+
+// Confidence is a Brakeman confidence level such as "High", "Medium" or "Weak".
+// Older Brakeman versions emit it as a number (0 = High, 1 = Medium, 2 = Weak);
+// those are normalized to their names when decoding.
+type Confidence string
+
+var confidenceLevels = []Confidence{"High", "Medium", "Weak"}
+
+func (c *Confidence) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*c = Confidence(name)
+		return nil
+	}
+
+	var level int
+	if err := json.Unmarshal(data, &level); err != nil {
+		return fmt.Errorf("confidence must be a string or a number: %s", data)
+	}
+	if level < 0 || level >= len(confidenceLevels) {
+		return fmt.Errorf("unknown confidence level %d", level)
+	}
+	*c = confidenceLevels[level]
+	return nil
 }
 
 // Parse decodes a Brakeman JSON report from r.
@@ -3,6 +3,7 @@ package brakeman_test
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Omochice/brakeman-to-codequality/brakeman"
 )
@@ -30,6 +31,166 @@ func TestParse(t *testing.T) {
 		}
 	})
 
+	t.Run("decodes the full warning schema", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"SQL Injection","warning_code":0,"fingerprint":"abc123","check_name":"SQL","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"link":"https://brakemanscanner.org/docs/warning_types/sql_injection/","code":"User.where(params[:q])","render_path":[{"type":"controller","class":"UsersController","method":"index","line":5,"file":"app/controllers/users_controller.rb","rendered":{"name":"users/index","file":"app/views/users/index.html.erb"}}],"location":{"type":"method","class":"User","method":"search"},"user_input":"params[:q]","confidence":"High","cwe_id":[89]}]}`
+		reader := strings.NewReader(input)
+
+		report, err := brakeman.Parse(reader)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		warning := report.Warnings[0]
+		if warning.CheckName != "SQL" {
+			t.Fatalf("got %v, want %v", warning.CheckName, "SQL")
+		}
+		if warning.Link != "https://brakemanscanner.org/docs/warning_types/sql_injection/" {
+			t.Fatalf("got %v, want %v", warning.Link, "https://brakemanscanner.org/docs/warning_types/sql_injection/")
+		}
+		if warning.UserInput != "params[:q]" {
+			t.Fatalf("got %v, want %v", warning.UserInput, "params[:q]")
+		}
+		if warning.Location == nil || warning.Location.Class != "User" || warning.Location.Method != "search" {
+			t.Fatalf("unexpected location: %+v", warning.Location)
+		}
+		if len(warning.RenderPath) != 1 || warning.RenderPath[0].Rendered == nil || warning.RenderPath[0].Rendered.Name != "users/index" {
+			t.Fatalf("unexpected render path: %+v", warning.RenderPath)
+		}
+		if len(warning.CWEID) != 1 || warning.CWEID[0] != 89 {
+			t.Fatalf("got %v, want %v", warning.CWEID, []int{89})
+		}
+	})
+
+	t.Run("decodes warning code", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"Cross-Site Scripting","warning_code":2}]}`
+		reader := strings.NewReader(input)
+
+		report, err := brakeman.Parse(reader)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if report.Warnings[0].WarningCode == nil || *report.Warnings[0].WarningCode != 2 {
+			t.Fatalf("got %v, want %v", report.Warnings[0].WarningCode, 2)
+		}
+	})
+
+	t.Run("leaves a missing warning code unset", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"Cross-Site Scripting"}]}`
+		reader := strings.NewReader(input)
+
+		report, err := brakeman.Parse(reader)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if report.Warnings[0].WarningCode != nil {
+			t.Fatalf("got %v, want nil", *report.Warnings[0].WarningCode)
+		}
+	})
+
+	t.Run("accepts null optional fields", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"Redirect","line":null,"code":null,"location":null,"user_input":null,"render_path":null,"confidence":"Weak"}]}`
+		reader := strings.NewReader(input)
+
+		report, err := brakeman.Parse(reader)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if report.Warnings[0].Location != nil {
+			t.Fatalf("expected nil, got %v", report.Warnings[0].Location)
+		}
+	})
+
+	t.Run("normalizes numeric confidence", func(t *testing.T) {
+		tests := []struct {
+			input string
+			want  brakeman.Confidence
+		}{
+			{input: "0", want: "High"},
+			{input: "1", want: "Medium"},
+			{input: "2", want: "Weak"},
+		}
+
+		for _, tt := range tests {
+			reader := strings.NewReader(`{"warnings":[{"confidence":` + tt.input + `}]}`)
+
+			report, err := brakeman.Parse(reader)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if report.Warnings[0].Confidence != tt.want {
+				t.Fatalf("got %v, want %v", report.Warnings[0].Confidence, tt.want)
+			}
+		}
+	})
+
+	t.Run("returns error for unknown numeric confidence", func(t *testing.T) {
+		reader := strings.NewReader(`{"warnings":[{"confidence":7}]}`)
+
+		_, err := brakeman.Parse(reader)
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+	})
+
+	t.Run("decodes scan_info", func(t *testing.T) {
+		input := `{"scan_info":{"app_path":"/app","rails_version":"7.1.2","ruby_version":"3.2.2","brakeman_version":"6.1.0","checks_performed":["SQL","CrossSiteScripting"],"start_time":"2024-01-15 10:00:00 +0000","end_time":"2024-01-15 10:00:05 +0000","duration":5.12,"number_of_controllers":3,"number_of_models":2,"number_of_templates":7,"security_warnings":1},"warnings":[]}`
+		reader := strings.NewReader(input)
+
+		report, err := brakeman.Parse(reader)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		info := report.ScanInfo
+		if info.BrakemanVersion != "6.1.0" {
+			t.Fatalf("got %v, want %v", info.BrakemanVersion, "6.1.0")
+		}
+		if info.RailsVersion != "7.1.2" {
+			t.Fatalf("got %v, want %v", info.RailsVersion, "7.1.2")
+		}
+		if info.AppPath != "/app" {
+			t.Fatalf("got %v, want %v", info.AppPath, "/app")
+		}
+		if len(info.ChecksPerformed) != 2 {
+			t.Fatalf("expected length %d, got %d", 2, len(info.ChecksPerformed))
+		}
+		if info.StartTime != "2024-01-15 10:00:00 +0000" {
+			t.Fatalf("got %v, want %v", info.StartTime, "2024-01-15 10:00:00 +0000")
+		}
+		started, err := info.StartedAt()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !started.Equal(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)) {
+			t.Fatalf("got %v, want %v", started, time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC))
+		}
+		if info.Duration != 5.12 {
+			t.Fatalf("got %v, want %v", info.Duration, 5.12)
+		}
+		if info.NumberOfControllers != 3 || info.NumberOfModels != 2 || info.NumberOfTemplates != 7 {
+			t.Fatalf("unexpected counts: %+v", info)
+		}
+	})
+
+	t.Run("decodes errors and obsolete entries", func(t *testing.T) {
+		input := `{"warnings":[],"errors":[{"error":"syntax error, unexpected end-of-input Could not parse app/views/users/edit.html.erb","location":"/usr/local/bundle/gems/ruby_parser-3.21.0/lib/ruby_parser_extras.rb:1218:in 'on_error'"}],"obsolete":["abc123"]}`
+		reader := strings.NewReader(input)
+
+		report, err := brakeman.Parse(reader)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(report.Errors) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(report.Errors))
+		}
+		if report.Errors[0].File() != "app/views/users/edit.html.erb" {
+			t.Fatalf("got %v, want %v", report.Errors[0].File(), "app/views/users/edit.html.erb")
+		}
+		if len(report.Obsolete) != 1 || report.Obsolete[0].Fingerprint != "abc123" {
+			t.Fatalf("got %v, want %v", report.Obsolete, []string{"abc123"})
+		}
+	})
+
 	t.Run("returns error for invalid JSON", func(t *testing.T) {
 		input := `{invalid json`
 		reader := strings.NewReader(input)
@@ -75,3 +236,50 @@ func TestParse(t *testing.T) {
 		}
 	})
 }
+
+func TestErrorFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		error brakeman.Error
+		want  string
+	}{
+		{
+			name:  "ruby parse error",
+			error: brakeman.Error{Error: "syntax error, unexpected end-of-input Could not parse app/models/user.rb", Location: "/usr/local/bundle/gems/ruby_parser-3.21.0/lib/ruby_parser_extras.rb:1218:in 'on_error'"},
+			want:  "app/models/user.rb",
+		},
+		{
+			name:  "template parse error",
+			error: brakeman.Error{Error: "unterminated string meets end of file", Location: "could not parse app/views/users/edit.html.erb"},
+			want:  "app/views/users/edit.html.erb",
+		},
+		{
+			name:  "processing error",
+			error: brakeman.Error{Error: "undefined method 'each' for nil While processing app/controllers/users_controller.rb", Location: "/usr/local/bundle/gems/brakeman-6.1.0/lib/brakeman/processor.rb:12:in 'process'"},
+			want:  "app/controllers/users_controller.rb",
+		},
+		{
+			name:  "parser timeout",
+			error: brakeman.Error{Error: "Parsing app/models/huge.rb took too long (> 10 seconds). Try increasing the limit with --parser-timeout"},
+			want:  "app/models/huge.rb",
+		},
+		{
+			name:  "rebased",
+			error: brakeman.Error{Error: "Could not parse app/models/user.rb", AppRoot: "engines/billing"},
+			want:  "engines/billing/app/models/user.rb",
+		},
+		{
+			name:  "no file",
+			error: brakeman.Error{Error: "undefined method 'each' for nil", Location: "/usr/local/bundle/gems/brakeman-6.1.0/lib/brakeman/checks/check_sql.rb:42:in 'process'"},
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.error.File(); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package brakeman
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Decoder reads the warnings of a Brakeman JSON report one at a time,
+// so that memory use does not grow with the number of warnings.
+type Decoder struct {
+	decoder *json.Decoder
+	report  Report
+	started bool
+	inArray bool
+	done    bool
+}
+
+// NewDecoder returns a Decoder reading from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{decoder: json.NewDecoder(r)}
+}
+
+// Next returns the next warning. It returns io.EOF once the whole report has been read.
+func (d *Decoder) Next() (Warning, error) {
+	if d.done {
+		return Warning{}, io.EOF
+	}
+
+	if !d.started {
+		if err := d.expectDelim('{'); err != nil {
+			return Warning{}, err
+		}
+		d.started = true
+	}
+
+	for {
+		if d.inArray {
+			if d.decoder.More() {
+				var warning Warning
+				if err := d.decoder.Decode(&warning); err != nil {
+					return Warning{}, err
+				}
+				return warning, nil
+			}
+			if err := d.expectDelim(']'); err != nil {
+				return Warning{}, err
+			}
+			d.inArray = false
+		}
+
+		if !d.decoder.More() {
+			if err := d.expectDelim('}'); err != nil {
+				return Warning{}, err
+			}
+			d.done = true
+			return Warning{}, io.EOF
+		}
+
+		if err := d.field(); err != nil {
+			return Warning{}, err
+		}
+	}
+}
+
+// Report returns everything but the warnings read so far.
+// Sections that come after the warnings in the document, such as errors and
+// obsolete entries, are complete only once Next has returned io.EOF.
+func (d *Decoder) Report() *Report {
+	report := d.report
+	report.Warnings = []Warning{}
+	return &report
+}
+
+// field reads the next key of the top-level object and its value, except for
+// the warnings array which is left open for Next.
+func (d *Decoder) field() error {
+	token, err := d.decoder.Token()
+	if err != nil {
+		return err
+	}
+	key, ok := token.(string)
+	if !ok {
+		return fmt.Errorf("unexpected token %v in report", token)
+	}
+
+	switch key {
+	case "warnings":
+		token, err := d.decoder.Token()
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			return nil
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return fmt.Errorf("expected warnings to be an array, got %v", token)
+		}
+		d.inArray = true
+		return nil
+	case "scan_info":
+		return d.decoder.Decode(&d.report.ScanInfo)
+	case "errors":
+		return d.decoder.Decode(&d.report.Errors)
+	case "obsolete":
+		return d.decoder.Decode(&d.report.Obsolete)
+	default:
+		var skipped json.RawMessage
+		return d.decoder.Decode(&skipped)
+	}
+}
+
+func (d *Decoder) expectDelim(want json.Delim) error {
+	token, err := d.decoder.Token()
+	if err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("expected %v in report, got %v", want, token)
+	}
+	return nil
+}
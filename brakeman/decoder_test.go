@@ -0,0 +1,100 @@
+package brakeman_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+)
+
+func decodeAll(t *testing.T, d *brakeman.Decoder) []brakeman.Warning {
+	t.Helper()
+	var warnings []brakeman.Warning
+	for {
+		warning, err := d.Next()
+		if errors.Is(err, io.EOF) {
+			return warnings
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		warnings = append(warnings, warning)
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	t.Run("yields warnings one at a time", func(t *testing.T) {
+		input := `{"scan_info":{"brakeman_version":"6.1.0"},"warnings":[{"warning_type":"SQL Injection","fingerprint":"fp1"},{"warning_type":"Cross-Site Scripting","fingerprint":"fp2"}],"ignored_warnings":[{"fingerprint":"fp3"}],"errors":[{"error":"parse error","location":"app/views/a.erb"}],"obsolete":["fp4"]}`
+		d := brakeman.NewDecoder(strings.NewReader(input))
+
+		first, err := d.Next()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if first.Fingerprint != "fp1" {
+			t.Fatalf("got %v, want %v", first.Fingerprint, "fp1")
+		}
+		if d.Report().ScanInfo.BrakemanVersion != "6.1.0" {
+			t.Fatalf("got %v, want %v", d.Report().ScanInfo.BrakemanVersion, "6.1.0")
+		}
+
+		rest := decodeAll(t, d)
+		if len(rest) != 1 || rest[0].Fingerprint != "fp2" {
+			t.Fatalf("unexpected warnings: %+v", rest)
+		}
+
+		report := d.Report()
+		if len(report.Errors) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(report.Errors))
+		}
+		if len(report.Obsolete) != 1 || report.Obsolete[0].Fingerprint != "fp4" {
+			t.Fatalf("got %v, want %v", report.Obsolete, []string{"fp4"})
+		}
+
+		if _, err := d.Next(); !errors.Is(err, io.EOF) {
+			t.Fatalf("expected io.EOF, got %v", err)
+		}
+	})
+
+	t.Run("handles missing and null warnings", func(t *testing.T) {
+		for _, input := range []string{`{}`, `{"warnings":null}`, `{"warnings":[]}`} {
+			warnings := decodeAll(t, brakeman.NewDecoder(strings.NewReader(input)))
+			if len(warnings) != 0 {
+				t.Fatalf("expected length %d, got %d", 0, len(warnings))
+			}
+		}
+	})
+
+	t.Run("returns error for invalid JSON", func(t *testing.T) {
+		d := brakeman.NewDecoder(strings.NewReader(`{invalid json`))
+		if _, err := d.Next(); err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+	})
+
+	t.Run("returns error for truncated input", func(t *testing.T) {
+		d := brakeman.NewDecoder(strings.NewReader(`{"warnings":[{"fingerprint":"fp1"}`))
+		if _, err := d.Next(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := d.Next(); err == nil || errors.Is(err, io.EOF) {
+			t.Fatalf("expected error other than io.EOF, got %v", err)
+		}
+	})
+
+	t.Run("returns error when warnings is not an array", func(t *testing.T) {
+		d := brakeman.NewDecoder(strings.NewReader(`{"warnings":{}}`))
+		if _, err := d.Next(); err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+	})
+
+	t.Run("returns error for empty input", func(t *testing.T) {
+		d := brakeman.NewDecoder(strings.NewReader(""))
+		if _, err := d.Next(); err == nil || errors.Is(err, io.EOF) {
+			t.Fatalf("expected error other than io.EOF, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package brakeman
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Ignore is the content of a brakeman.ignore file, which lists warnings
+// that have been triaged as false positives.
+type Ignore struct {
+	IgnoredWarnings []IgnoredWarning `json:"ignored_warnings"`
+	Updated         string           `json:"updated"`
+	BrakemanVersion string           `json:"brakeman_version"`
+}
+
+// IgnoredWarning is a copy of the ignored warning together with the triage note.
+type IgnoredWarning struct {
+	Warning
+	Note string `json:"note"`
+}
+
+// ParseIgnore decodes a brakeman.ignore file from r.
+func ParseIgnore(r io.Reader) (*Ignore, error) {
+	var ignore Ignore
+
+	decoder := json.NewDecoder(r)
+	if err := decoder.Decode(&ignore); err != nil {
+		return nil, err
+	}
+
+	return &ignore, nil
+}
+
+// Lookup reports whether fingerprint is ignored and returns its note.
+func (i *Ignore) Lookup(fingerprint string) (string, bool) {
+	for _, ignored := range i.IgnoredWarnings {
+		if ignored.Fingerprint == fingerprint {
+			return ignored.Note, true
+		}
+	}
+	return "", false
+}
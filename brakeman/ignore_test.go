@@ -0,0 +1,60 @@
+package brakeman_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+)
+
+func TestParseIgnore(t *testing.T) {
+	t.Run("parses ignored warnings", func(t *testing.T) {
+		input := `{"ignored_warnings":[{"warning_type":"SQL Injection","warning_code":0,"fingerprint":"abc123","file":"app/models/user.rb","line":42,"note":"Sanitized upstream"}],"updated":"2024-01-15 10:00:00 +0000","brakeman_version":"6.1.0"}`
+
+		ignore, err := brakeman.ParseIgnore(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(ignore.IgnoredWarnings) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(ignore.IgnoredWarnings))
+		}
+		if ignore.IgnoredWarnings[0].Fingerprint != "abc123" {
+			t.Fatalf("got %v, want %v", ignore.IgnoredWarnings[0].Fingerprint, "abc123")
+		}
+		if ignore.IgnoredWarnings[0].Note != "Sanitized upstream" {
+			t.Fatalf("got %v, want %v", ignore.IgnoredWarnings[0].Note, "Sanitized upstream")
+		}
+	})
+
+	t.Run("returns error for invalid JSON", func(t *testing.T) {
+		_, err := brakeman.ParseIgnore(strings.NewReader(`{invalid json`))
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+	})
+}
+
+func TestIgnoreLookup(t *testing.T) {
+	ignore := &brakeman.Ignore{
+		IgnoredWarnings: []brakeman.IgnoredWarning{
+			{Warning: brakeman.Warning{Fingerprint: "abc123"}, Note: "False positive"},
+		},
+	}
+
+	t.Run("finds ignored fingerprint", func(t *testing.T) {
+		note, ok := ignore.Lookup("abc123")
+		if !ok {
+			t.Fatalf("expected true, got false")
+		}
+		if note != "False positive" {
+			t.Fatalf("got %v, want %v", note, "False positive")
+		}
+	})
+
+	t.Run("does not find other fingerprint", func(t *testing.T) {
+		_, ok := ignore.Lookup("def456")
+		if ok {
+			t.Fatalf("expected false, got true")
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package brakeman
+
+import (
+	"path"
+	"slices"
+)
+
+// Merge combines several reports into one.
+// Warnings sharing a file and a fingerprint are reported once, keeping the first
+// occurrence, and so are obsolete ignore entries. Brakeman fingerprints are
+// computed from paths relative to each application, so reports of different
+// applications should be rebased first to keep their warnings apart.
+// The scan info of the first report is kept, with the checks performed and the
+// file counts of all reports combined.
+func Merge(reports ...*Report) *Report {
+	merged := &Report{Warnings: []Warning{}}
+	seen := map[string]bool{}
+
+	for i, report := range reports {
+		if i == 0 {
+			merged.ScanInfo = report.ScanInfo
+			merged.ScanInfo.ChecksPerformed = slices.Clone(report.ScanInfo.ChecksPerformed)
+		} else {
+			for _, check := range report.ScanInfo.ChecksPerformed {
+				if !slices.Contains(merged.ScanInfo.ChecksPerformed, check) {
+					merged.ScanInfo.ChecksPerformed = append(merged.ScanInfo.ChecksPerformed, check)
+				}
+			}
+			merged.ScanInfo.NumberOfControllers += report.ScanInfo.NumberOfControllers
+			merged.ScanInfo.NumberOfModels += report.ScanInfo.NumberOfModels
+			merged.ScanInfo.NumberOfTemplates += report.ScanInfo.NumberOfTemplates
+		}
+
+		for _, warning := range report.Warnings {
+			if warning.Fingerprint != "" {
+				key := path.Clean(warning.File) + "\x00" + warning.Fingerprint
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+			}
+			merged.Warnings = append(merged.Warnings, warning)
+		}
+
+		merged.Errors = append(merged.Errors, report.Errors...)
+		for _, obsolete := range report.Obsolete {
+			if !slices.Contains(merged.Obsolete, obsolete) {
+				merged.Obsolete = append(merged.Obsolete, obsolete)
+			}
+		}
+	}
+
+	merged.ScanInfo.SecurityWarnings = len(merged.Warnings)
+
+	return merged
+}
+
+// Rebase prefixes every file path in the report with dir, which is
+// typically the location of the scanned application inside a repository,
+// and records dir as the AppRoot of each warning, error and obsolete entry.
+func (r *Report) Rebase(dir string) {
+	if path.Clean(dir) == "." {
+		return
+	}
+	for i := range r.Warnings {
+		warning := &r.Warnings[i]
+		warning.AppRoot = dir
+		warning.File = rebase(dir, warning.File)
+		for j := range warning.RenderPath {
+			entry := &warning.RenderPath[j]
+			entry.File = rebase(dir, entry.File)
+			if entry.Rendered != nil {
+				entry.Rendered.File = rebase(dir, entry.Rendered.File)
+			}
+		}
+	}
+	for i := range r.Errors {
+		r.Errors[i].AppRoot = dir
+	}
+	for i := range r.Obsolete {
+		r.Obsolete[i].AppRoot = dir
+	}
+}
+
+func rebase(dir string, file string) string {
+	if file == "" || path.IsAbs(file) {
+		return file
+	}
+	return path.Join(dir, file)
+}
@@ -0,0 +1,135 @@
+package brakeman_test
+
+import (
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+)
+
+func TestMerge(t *testing.T) {
+	t.Run("combines warnings and deduplicates by fingerprint", func(t *testing.T) {
+		first := &brakeman.Report{
+			ScanInfo: brakeman.ScanInfo{BrakemanVersion: "6.1.0", ChecksPerformed: []string{"SQL"}, NumberOfModels: 2},
+			Warnings: []brakeman.Warning{
+				{WarningType: "SQL Injection", Fingerprint: "fp1"},
+				{WarningType: "Redirect"},
+			},
+			Errors:   []brakeman.Error{{Error: "parse error", Location: "app/views/a.erb"}},
+			Obsolete: []brakeman.Obsolete{{Fingerprint: "old1"}},
+		}
+		second := &brakeman.Report{
+			ScanInfo: brakeman.ScanInfo{BrakemanVersion: "6.0.0", ChecksPerformed: []string{"SQL", "Redirect"}, NumberOfModels: 3},
+			Warnings: []brakeman.Warning{
+				{WarningType: "SQL Injection (duplicate)", Fingerprint: "fp1"},
+				{WarningType: "Cross-Site Scripting", Fingerprint: "fp2"},
+				{WarningType: "Redirect"},
+			},
+			Errors:   []brakeman.Error{{Error: "parse error", Location: "app/views/b.erb"}},
+			Obsolete: []brakeman.Obsolete{{Fingerprint: "old1"}, {Fingerprint: "old2"}},
+		}
+
+		merged := brakeman.Merge(first, second)
+		if len(merged.Warnings) != 4 {
+			t.Fatalf("expected length %d, got %d", 4, len(merged.Warnings))
+		}
+		if merged.Warnings[0].WarningType != "SQL Injection" {
+			t.Fatalf("got %v, want %v", merged.Warnings[0].WarningType, "SQL Injection")
+		}
+		if merged.ScanInfo.BrakemanVersion != "6.1.0" {
+			t.Fatalf("got %v, want %v", merged.ScanInfo.BrakemanVersion, "6.1.0")
+		}
+		if len(merged.ScanInfo.ChecksPerformed) != 2 {
+			t.Fatalf("got %v, want %v", merged.ScanInfo.ChecksPerformed, []string{"SQL", "Redirect"})
+		}
+		if merged.ScanInfo.NumberOfModels != 5 {
+			t.Fatalf("got %v, want %v", merged.ScanInfo.NumberOfModels, 5)
+		}
+		if len(merged.Errors) != 2 {
+			t.Fatalf("expected length %d, got %d", 2, len(merged.Errors))
+		}
+		if len(merged.Obsolete) != 2 {
+			t.Fatalf("got %v, want %v", merged.Obsolete, []string{"old1", "old2"})
+		}
+		if len(first.ScanInfo.ChecksPerformed) != 1 {
+			t.Fatalf("expected the first report to be left untouched, got %v", first.ScanInfo.ChecksPerformed)
+		}
+	})
+
+	t.Run("keeps warnings of rebased applications sharing a fingerprint", func(t *testing.T) {
+		first := &brakeman.Report{Warnings: []brakeman.Warning{{WarningType: "SQL Injection", File: "app/models/user.rb", Fingerprint: "fp1"}}}
+		second := &brakeman.Report{Warnings: []brakeman.Warning{{WarningType: "SQL Injection", File: "app/models/user.rb", Fingerprint: "fp1"}}}
+		first.Rebase("engines/a")
+		second.Rebase("engines/b")
+
+		merged := brakeman.Merge(first, second)
+		if len(merged.Warnings) != 2 {
+			t.Fatalf("expected length %d, got %d", 2, len(merged.Warnings))
+		}
+		if merged.Warnings[1].File != "engines/b/app/models/user.rb" {
+			t.Fatalf("got %v, want %v", merged.Warnings[1].File, "engines/b/app/models/user.rb")
+		}
+	})
+
+	t.Run("keeps obsolete entries of rebased applications apart", func(t *testing.T) {
+		first := &brakeman.Report{Obsolete: []brakeman.Obsolete{{Fingerprint: "old1"}}}
+		second := &brakeman.Report{Obsolete: []brakeman.Obsolete{{Fingerprint: "old1"}}}
+		first.Rebase("engines/a")
+		second.Rebase("engines/b")
+
+		merged := brakeman.Merge(first, second)
+		if len(merged.Obsolete) != 2 {
+			t.Fatalf("expected length %d, got %d", 2, len(merged.Obsolete))
+		}
+	})
+
+	t.Run("returns empty report without input", func(t *testing.T) {
+		merged := brakeman.Merge()
+		if merged.Warnings == nil || len(merged.Warnings) != 0 {
+			t.Fatalf("expected empty warnings, got %v", merged.Warnings)
+		}
+	})
+}
+
+func TestRebase(t *testing.T) {
+	report := &brakeman.Report{
+		Warnings: []brakeman.Warning{
+			{
+				File: "app/views/users/show.html.erb",
+				RenderPath: []brakeman.RenderPathEntry{
+					{File: "app/controllers/users_controller.rb", Rendered: &brakeman.Rendered{File: "app/views/users/show.html.erb"}},
+				},
+			},
+			{File: "./app/models/user.rb"},
+			{File: "/abs/app/models/post.rb"},
+		},
+		Errors:   []brakeman.Error{{Error: "parse error Could not parse app/views/a.erb", Location: "/usr/local/bundle/gems/ruby_parser-3.21.0/lib/ruby_parser_extras.rb:1218:in 'on_error'"}},
+		Obsolete: []brakeman.Obsolete{{Fingerprint: "old1"}},
+	}
+
+	report.Rebase("engines/billing")
+
+	if report.Warnings[0].File != "engines/billing/app/views/users/show.html.erb" {
+		t.Fatalf("got %v, want %v", report.Warnings[0].File, "engines/billing/app/views/users/show.html.erb")
+	}
+	if report.Warnings[0].RenderPath[0].File != "engines/billing/app/controllers/users_controller.rb" {
+		t.Fatalf("got %v, want %v", report.Warnings[0].RenderPath[0].File, "engines/billing/app/controllers/users_controller.rb")
+	}
+	if report.Warnings[0].RenderPath[0].Rendered.File != "engines/billing/app/views/users/show.html.erb" {
+		t.Fatalf("got %v, want %v", report.Warnings[0].RenderPath[0].Rendered.File, "engines/billing/app/views/users/show.html.erb")
+	}
+	if report.Warnings[1].File != "engines/billing/app/models/user.rb" {
+		t.Fatalf("got %v, want %v", report.Warnings[1].File, "engines/billing/app/models/user.rb")
+	}
+	if report.Errors[0].File() != "engines/billing/app/views/a.erb" {
+		t.Fatalf("got %v, want %v", report.Errors[0].File(), "engines/billing/app/views/a.erb")
+	}
+	if report.Obsolete[0].AppRoot != "engines/billing" {
+		t.Fatalf("got %v, want %v", report.Obsolete[0].AppRoot, "engines/billing")
+	}
+	if report.Warnings[0].AppRoot != "engines/billing" {
+		t.Fatalf("got %v, want %v", report.Warnings[0].AppRoot, "engines/billing")
+	}
+	if report.Warnings[2].File != "/abs/app/models/post.rb" {
+		t.Fatalf("got %v, want %v", report.Warnings[2].File, "/abs/app/models/post.rb")
+	}
+}
@@ -0,0 +1,43 @@
+package checkstyle
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// Version is the Checkstyle release whose report format is produced.
+const Version = "4.3"
+
+// Report is the root element of a Checkstyle XML report.
+type Report struct {
+	XMLName xml.Name `xml:"checkstyle"`
+	Version string   `xml:"version,attr"`
+	Files   []File   `xml:"file"`
+}
+
+type File struct {
+	Name   string  `xml:"name,attr"`
+	Errors []Error `xml:"error"`
+}
+
+type Error struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// Write encodes report as indented XML into w.
+func Write(report *Report, w io.Writer) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
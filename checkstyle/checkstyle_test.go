@@ -0,0 +1,51 @@
+package checkstyle_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/checkstyle"
+)
+
+func TestWrite(t *testing.T) {
+	t.Run("writes Checkstyle XML", func(t *testing.T) {
+		report := &checkstyle.Report{
+			Version: checkstyle.Version,
+			Files: []checkstyle.File{
+				{
+					Name: "app/models/user.rb",
+					Errors: []checkstyle.Error{
+						{Line: 42, Severity: "error", Message: `Possible SQL injection near "id" & <name>`, Source: "brakeman.SQL"},
+					},
+				},
+			},
+		}
+
+		var buf bytes.Buffer
+		if err := checkstyle.Write(report, &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		output := buf.String()
+		if !strings.HasPrefix(output, "<?xml") {
+			t.Fatalf("expected %q to start with the XML declaration", output)
+		}
+		if strings.Contains(output, "column=") {
+			t.Fatalf("expected column to be omitted, got %q", output)
+		}
+
+		var decoded checkstyle.Report
+		if err := xml.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("failed to decode output as XML: %v", err)
+		}
+		got := decoded.Files[0].Errors[0]
+		if got.Message != report.Files[0].Errors[0].Message {
+			t.Fatalf("got %q, want %q", got.Message, report.Files[0].Errors[0].Message)
+		}
+		if got.Line != 42 {
+			t.Fatalf("got %v, want %v", got.Line, 42)
+		}
+	})
+}
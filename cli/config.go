@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"slices"
+
+	"github.com/jessevdk/go-flags"
+	"go.yaml.in/yaml/v3"
+)
+
+// DefaultConfigFile is looked up in the working directory when --config is not given.
+const DefaultConfigFile = ".brakeman-to-codequality.yml"
+
+// configFile returns the config file to load, or an empty string when there is none.
+func configFile(opts *Options) (string, error) {
+	if opts.Config != "" {
+		return opts.Config, nil
+	}
+
+	if _, err := os.Stat(DefaultConfigFile); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	return DefaultConfigFile, nil
+}
+
+// applyConfig sets the options found in the YAML file at path.
+// Keys are the long flag names. Options already given on the command line are left untouched.
+func applyConfig(parser *flags.Parser, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var values map[string]any
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		option := parser.FindOptionByLongName(key)
+		if option == nil || key == "config" || key == "version" || key == "help" {
+			return fmt.Errorf("%s: unknown key %q", path, key)
+		}
+		if option.IsSet() && !option.IsSetDefault() {
+			continue
+		}
+		if err := setOption(option, values[key]); err != nil {
+			return fmt.Errorf("%s: %s: %w", path, key, err)
+		}
+	}
+
+	return nil
+}
+
+func setOption(option *flags.Option, value any) error {
+	list, isList := value.([]any)
+	if !isList {
+		list = []any{value}
+	} else if option.Field().Type.Kind() != reflect.Slice {
+		return errors.New("expected a single value, got a list")
+	}
+
+	for _, item := range list {
+		s := fmt.Sprint(item)
+		if err := option.Set(&s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
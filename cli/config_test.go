@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	return path
+}
+
+func TestParseConfig(t *testing.T) {
+	t.Run("discovers config file in working directory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, DefaultConfigFile, "format: sarif\nignore-file: config/brakeman.ignore\n")
+		t.Chdir(dir)
+
+		opts, err := Parse([]string{"report.json"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if opts.Format != "sarif" {
+			t.Fatalf("got %q, want %q", opts.Format, "sarif")
+		}
+		if opts.IgnoreFile != "config/brakeman.ignore" {
+			t.Fatalf("got %q, want %q", opts.IgnoreFile, "config/brakeman.ignore")
+		}
+	})
+
+	t.Run("reads config file given with --config", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		path := writeConfig(t, t.TempDir(), "policy.yml", "ignore-action: downgrade\n")
+
+		opts, err := Parse([]string{"--config", path, "report.json"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if opts.IgnoreAction != "downgrade" {
+			t.Fatalf("got %q, want %q", opts.IgnoreAction, "downgrade")
+		}
+	})
+
+	t.Run("command line flags override config values", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, DefaultConfigFile, "format: sarif\n")
+		t.Chdir(dir)
+
+		opts, err := Parse([]string{"--format", "gitlab-sast", "report.json"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if opts.Format != "gitlab-sast" {
+			t.Fatalf("got %q, want %q", opts.Format, "gitlab-sast")
+		}
+	})
+
+	t.Run("command line flags override config values even when they equal the default", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, DefaultConfigFile, "format: sarif\n")
+		t.Chdir(dir)
+
+		opts, err := Parse([]string{"--format", "codequality", "report.json"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if opts.Format != "codequality" {
+			t.Fatalf("got %q, want %q", opts.Format, "codequality")
+		}
+	})
+
+	t.Run("command line flags turn off booleans enabled in the config", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, DefaultConfigFile, "strict: true\nsummary: true\n")
+		t.Chdir(dir)
+
+		opts, err := Parse([]string{"--strict=false", "report.json"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if opts.Strict {
+			t.Fatal("expected --strict=false to override the config")
+		}
+		if !opts.Summary {
+			t.Fatal("expected summary to be read from the config")
+		}
+	})
+
+	t.Run("returns error for unknown key", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, DefaultConfigFile, "formt: sarif\n")
+		t.Chdir(dir)
+
+		_, err := Parse([]string{"report.json"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), `unknown key "formt"`) {
+			t.Fatalf("expected %q to contain %q", err.Error(), `unknown key "formt"`)
+		}
+	})
+
+	t.Run("returns error for invalid value", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, DefaultConfigFile, "format: xml\n")
+		t.Chdir(dir)
+
+		_, err := Parse([]string{"report.json"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("returns error for list given to a single value option", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, DefaultConfigFile, "format: [sarif, codequality]\n")
+		t.Chdir(dir)
+
+		_, err := Parse([]string{"report.json"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("returns error when given config file does not exist", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+
+		_, err := Parse([]string{"--config", "missing.yml", "report.json"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("accepts empty config file", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, dir, DefaultConfigFile, "")
+		t.Chdir(dir)
+
+		opts, err := Parse([]string{"report.json"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if opts.Format != "codequality" {
+			t.Fatalf("got %q, want %q", opts.Format, "codequality")
+		}
+	})
+}
@@ -2,33 +2,157 @@ package cli
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/jessevdk/go-flags"
 )
 
 func Parse(args []string) (*Options, error) {
+	opts, remaining, err := parse(args, flags.HelpFlag, "[OPTIONS] <file path|glob|->...")
+	if err != nil || opts.Version {
+		return opts, err
+	}
+
+	if len(remaining) == 0 {
+		return nil, errors.New("at least one argument required (file path, glob, or \"-\" for stdin)")
+	}
+	opts.Sources, err = expandSources(remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateFormat(opts); err != nil {
+		return nil, err
+	}
+	if opts.Stream {
+		if err := validateStream(opts); err != nil {
+			return nil, err
+		}
+	}
+
+	return opts, nil
+}
+
+// ParseRun parses the arguments of the run subcommand, which follow "run".
+// Positional arguments and everything after "--" are passed to Brakeman.
+func ParseRun(args []string) (*Options, error) {
+	opts, remaining, err := parse(args, flags.HelpFlag|flags.PassDoubleDash, "run [OPTIONS] [-- BRAKEMAN ARGUMENTS]")
+	if err != nil || opts.Version {
+		return opts, err
+	}
+
+	if opts.Stream {
+		return nil, errors.New("--stream cannot be combined with run")
+	}
+	if err := validateFormat(opts); err != nil {
+		return nil, err
+	}
+	opts.Run = true
+	opts.BrakemanArgs = remaining
+
+	return opts, nil
+}
+
+// parse reads the options in args, then those of the config file.
+// It returns the arguments that are not options.
+func parse(args []string, options flags.Options, usage string) (*Options, []string, error) {
 	var opts Options
-	parser := flags.NewParser(&opts, flags.HelpFlag)
-	parser.Usage = "[OPTIONS] <file path|->"
+	parser := flags.NewParser(&opts, options|flags.AllowBoolValues)
+	parser.Usage = usage
 	remaining, err := parser.ParseArgs(args)
 	if err != nil {
 		if ferr, ok := err.(*flags.Error); ok && ferr.Type == flags.ErrHelp {
 			var buf bytes.Buffer
 			parser.WriteHelp(&buf)
-			return nil, NewHelpError(buf.String())
+			return nil, nil, NewHelpError(buf.String())
 		}
-		return nil, err
+		return nil, nil, err
 	}
 
 	if opts.Version {
-		return &opts, nil
+		return &opts, nil, nil
+	}
+
+	config, err := configFile(&opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if config != "" {
+		if err := applyConfig(parser, config); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return &opts, remaining, nil
+}
+
+// validateFormat rejects options the selected format cannot honor.
+func validateFormat(opts *Options) error {
+	if opts.Format == "codequality" {
+		return nil
+	}
+	switch {
+	case opts.IncludeErrors:
+		return fmt.Errorf("--include-errors supports only the codequality format, got %q", opts.Format)
+	case opts.IncludeObsolete:
+		return fmt.Errorf("--include-obsolete supports only the codequality format, got %q", opts.Format)
+	case opts.Summary && opts.Format == "text":
+		return errors.New("--summary cannot be combined with the text format, which already ends with the summary")
 	}
+	return nil
+}
+
+// validateStream rejects options that need the whole report in memory.
+func validateStream(opts *Options) error {
+	switch {
+	case opts.Format != "codequality":
+		return fmt.Errorf("--stream supports only the codequality format, got %q", opts.Format)
+	case len(opts.Sources) != 1:
+		return fmt.Errorf("--stream requires exactly one input, got %d", len(opts.Sources))
+	case opts.PrefixAppPath:
+		return errors.New("--stream cannot be combined with --prefix-app-path")
+	case opts.ShowFixed:
+		return errors.New("--stream cannot be combined with --show-fixed")
+	case opts.Summary:
+		return errors.New("--stream cannot be combined with --summary")
+	case opts.Fingerprint == "path-normalized":
+		return errors.New("--stream cannot be combined with --fingerprint path-normalized")
+	}
+	return nil
+}
+
+// expandSources expands glob patterns in args. Stdin may be given at most once.
+func expandSources(args []string) ([]string, error) {
+	var sources []string
+	stdin := false
 
-	if len(remaining) != 1 {
-		return nil, fmt.Errorf("exactly one argument required (file path or \"-\" for stdin), got %d", len(remaining))
+	for _, arg := range args {
+		if arg == "-" {
+			if stdin {
+				return nil, errors.New("\"-\" (stdin) can be given only once")
+			}
+			stdin = true
+			sources = append(sources, arg)
+			continue
+		}
+
+		if !strings.ContainsAny(arg, "*?[") {
+			sources = append(sources, arg)
+			continue
+		}
+
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", arg, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("%s: no files match", arg)
+		}
+		sources = append(sources, matches...)
 	}
-	opts.Source = remaining[0]
 
-	return &opts, nil
+	return sources, nil
 }
@@ -1,6 +1,11 @@
 package cli
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
 
 func TestParse(t *testing.T) {
 	t.Run("returns error when no positional argument given", func(t *testing.T) {
@@ -10,30 +15,65 @@ func TestParse(t *testing.T) {
 		}
 	})
 
-	t.Run("returns error when multiple positional arguments given", func(t *testing.T) {
-		_, err := Parse([]string{"a.json", "b.json"})
-		if err == nil {
-			t.Fatal("expected error, got nil")
+	t.Run("sets Sources to multiple positional arguments", func(t *testing.T) {
+		opts, err := Parse([]string{"a.json", "b.json"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !slices.Equal(opts.Sources, []string{"a.json", "b.json"}) {
+			t.Fatalf("got %q, want %q", opts.Sources, []string{"a.json", "b.json"})
 		}
 	})
 
-	t.Run("sets Source to the positional argument", func(t *testing.T) {
+	t.Run("sets Sources to the positional argument", func(t *testing.T) {
 		opts, err := Parse([]string{"report.json"})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if opts.Source != "report.json" {
-			t.Fatalf("got %q, want %q", opts.Source, "report.json")
+		if !slices.Equal(opts.Sources, []string{"report.json"}) {
+			t.Fatalf("got %q, want %q", opts.Sources, []string{"report.json"})
 		}
 	})
 
-	t.Run("sets Source to dash for stdin", func(t *testing.T) {
+	t.Run("sets Sources to dash for stdin", func(t *testing.T) {
 		opts, err := Parse([]string{"-"})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if opts.Source != "-" {
-			t.Fatalf("got %q, want %q", opts.Source, "-")
+		if !slices.Equal(opts.Sources, []string{"-"}) {
+			t.Fatalf("got %q, want %q", opts.Sources, []string{"-"})
+		}
+	})
+
+	t.Run("returns error when dash is given twice", func(t *testing.T) {
+		_, err := Parse([]string{"-", "-"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("expands glob patterns", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, name := range []string{"a.json", "b.json", "c.txt"} {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0o644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+		}
+
+		opts, err := Parse([]string{filepath.Join(dir, "*.json")})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json")}
+		if !slices.Equal(opts.Sources, want) {
+			t.Fatalf("got %q, want %q", opts.Sources, want)
+		}
+	})
+
+	t.Run("returns error when glob matches nothing", func(t *testing.T) {
+		_, err := Parse([]string{filepath.Join(t.TempDir(), "*.json")})
+		if err == nil {
+			t.Fatal("expected error, got nil")
 		}
 	})
 
@@ -46,4 +86,126 @@ func TestParse(t *testing.T) {
 			t.Fatal("expected Version to be true")
 		}
 	})
+
+	t.Run("defaults Format to codequality", func(t *testing.T) {
+		opts, err := Parse([]string{"report.json"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if opts.Format != "codequality" {
+			t.Fatalf("got %q, want %q", opts.Format, "codequality")
+		}
+	})
+
+	t.Run("sets Format from flag", func(t *testing.T) {
+		opts, err := Parse([]string{"--format", "sarif", "report.json"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if opts.Format != "sarif" {
+			t.Fatalf("got %q, want %q", opts.Format, "sarif")
+		}
+	})
+
+	t.Run("returns error for unknown format", func(t *testing.T) {
+		_, err := Parse([]string{"--format", "xml", "report.json"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("returns error for --include-errors with another format", func(t *testing.T) {
+		_, err := Parse([]string{"--include-errors", "--format", "sarif", "report.json"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("returns error for --include-obsolete with another format", func(t *testing.T) {
+		_, err := Parse([]string{"--include-obsolete", "--format", "junit", "report.json"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("returns error for --stream with path-normalized fingerprints", func(t *testing.T) {
+		_, err := Parse([]string{"--stream", "--fingerprint", "path-normalized", "report.json"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("returns error for --summary with the text format", func(t *testing.T) {
+		_, err := Parse([]string{"--summary", "--format", "text", "report.json"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("accepts --stream with a single codequality input", func(t *testing.T) {
+		opts, err := Parse([]string{"--stream", "report.json"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !opts.Stream {
+			t.Fatal("expected Stream to be true")
+		}
+	})
+
+	t.Run("returns error for --stream with another format", func(t *testing.T) {
+		_, err := Parse([]string{"--stream", "--format", "sarif", "report.json"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("returns error for --stream with --summary", func(t *testing.T) {
+		_, err := Parse([]string{"--stream", "--summary", "report.json"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("returns error for --stream with multiple inputs", func(t *testing.T) {
+		_, err := Parse([]string{"--stream", "a.json", "b.json"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestParseRun(t *testing.T) {
+	t.Run("passes positional arguments and those after -- to Brakeman", func(t *testing.T) {
+		opts, err := ParseRun([]string{"--fail-on", "major", "app", "--", "--no-pager", "-q"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !opts.Run {
+			t.Fatal("expected Run to be true")
+		}
+		if opts.FailOn != "major" {
+			t.Fatalf("got %q, want %q", opts.FailOn, "major")
+		}
+		want := []string{"app", "--no-pager", "-q"}
+		if !slices.Equal(opts.BrakemanArgs, want) {
+			t.Fatalf("got %v, want %v", opts.BrakemanArgs, want)
+		}
+	})
+
+	t.Run("defaults the Brakeman executable", func(t *testing.T) {
+		opts, err := ParseRun(nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if opts.Brakeman != "brakeman" {
+			t.Fatalf("got %q, want %q", opts.Brakeman, "brakeman")
+		}
+	})
+
+	t.Run("returns error for --stream", func(t *testing.T) {
+		_, err := ParseRun([]string{"--stream"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
 }
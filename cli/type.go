@@ -1,6 +1,29 @@
 package cli
 
 type Options struct {
-	Version bool `short:"v" long:"version" description:"Show application version"`
-	Source  string
+	Version         bool     `short:"v" long:"version" description:"Show application version"`
+	Config          string   `short:"c" long:"config" description:"Path to a YAML config file whose keys are long flag names (default: .brakeman-to-codequality.yml if present)"`
+	Format          string   `short:"f" long:"format" description:"Output format" choice:"codequality" choice:"sarif" choice:"gitlab-sast" choice:"checkstyle" choice:"junit" choice:"github-actions" choice:"rdjson" choice:"rdjsonl" choice:"sonarqube" choice:"text" default:"codequality"`
+	IgnoreFile      string   `long:"ignore-file" description:"Path to a brakeman.ignore file whose warnings are excluded"`
+	IgnoreAction    string   `long:"ignore-action" description:"What to do with ignored warnings" choice:"drop" choice:"downgrade" default:"drop"`
+	SeverityConfig  string   `long:"severity-config" description:"Path to a YAML or JSON file customizing the severity mapping"`
+	FailOn          string   `long:"fail-on" description:"Exit with status 2 when a violation has at least this severity" choice:"info" choice:"minor" choice:"major" choice:"critical" choice:"blocker"`
+	Baseline        string   `long:"baseline" description:"Path to a previous Brakeman or CodeQuality JSON report; only warnings not in it are reported"`
+	ShowFixed       bool     `long:"show-fixed" description:"List baseline warnings that are no longer reported on stderr"`
+	PrefixAppPath   bool     `long:"prefix-app-path" description:"Prefix warning paths with each report's app_path relative to the working directory"`
+	Fingerprint     string   `long:"fingerprint" description:"How fingerprints are produced" choice:"brakeman" choice:"computed" choice:"path-normalized" default:"brakeman"`
+	PathRoot        string   `long:"path-root" description:"Make absolute warning paths relative to this directory"`
+	StripPrefix     string   `long:"strip-prefix" description:"Remove this leading directory from warning paths"`
+	PathRewrites    []string `long:"path-rewrite" description:"Rewrite warning paths with a regular expression, written as from=to (repeatable)"`
+	PathPrefix      string   `long:"path-prefix" description:"Prepend this directory to warning paths"`
+	Strict          bool     `long:"strict" description:"Exit with status 3 without writing output when a warning lacks required fields"`
+	IncludeErrors   bool     `long:"include-errors" description:"Report files Brakeman could not parse as info issues (codequality format)"`
+	IncludeObsolete bool     `long:"include-obsolete" description:"Report obsolete brakeman.ignore entries as issues on the ignore file (codequality format)"`
+	Stream          bool     `long:"stream" description:"Convert one warning at a time to keep memory flat for very large reports (codequality format, single input)"`
+	Summary         bool     `long:"summary" description:"Also print a table of warnings by type and severity on stderr"`
+	Brakeman        string   `long:"brakeman" description:"Brakeman executable started by the run subcommand" default:"brakeman"`
+	Sources         []string
+	// Run is set when the run subcommand was given. BrakemanArgs are passed to Brakeman as is.
+	Run          bool
+	BrakemanArgs []string
 }
@@ -5,21 +5,49 @@ import (
 	"io"
 )
 
+// Violation is an issue as defined by the Code Climate engine specification,
+// which GitLab Code Quality is based on.
 type Violation struct {
-	Description string   `json:"description"`
-	CheckName   string   `json:"check_name"`
-	Fingerprint string   `json:"fingerprint"`
-	Severity    string   `json:"severity"`
-	Location    Location `json:"location"`
+	Type              string     `json:"type,omitempty"`
+	Description       string     `json:"description"`
+	CheckName         string     `json:"check_name"`
+	Fingerprint       string     `json:"fingerprint"`
+	Severity          string     `json:"severity"`
+	Categories        []string   `json:"categories,omitempty"`
+	EngineName        string     `json:"engine_name,omitempty"`
+	RemediationPoints int        `json:"remediation_points,omitempty"`
+	Location          Location   `json:"location"`
+	OtherLocations    []Location `json:"other_locations,omitempty"`
+	Content           *Content   `json:"content,omitempty"`
 }
 
+// Content holds additional details about a violation.
+// Body is rendered as Markdown by GitLab.
+type Content struct {
+	Body string `json:"body"`
+}
+
+// Location is a path with either a line range or a position range.
+// Brakeman reports no columns, so the converter fills Lines only.
 type Location struct {
-	Path  string `json:"path"`
-	Lines Lines  `json:"lines"`
+	Path      string     `json:"path"`
+	Lines     Lines      `json:"lines,omitzero"`
+	Positions *Positions `json:"positions,omitempty"`
 }
 
 type Lines struct {
 	Begin int `json:"begin"`
+	End   int `json:"end,omitempty"`
+}
+
+type Positions struct {
+	Begin Position `json:"begin"`
+	End   Position `json:"end"`
+}
+
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column,omitempty"`
 }
 
 // Write encodes violations as JSON into w.
@@ -30,3 +58,62 @@ func Write(violations []Violation, w io.Writer) error {
 	}
 	return nil
 }
+
+// Encoder writes violations as a JSON array one element at a time,
+// producing the same output as Write without holding every violation in memory.
+type Encoder struct {
+	w     io.Writer
+	count int
+}
+
+// NewEncoder returns an Encoder writing to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes violation as the next element of the array.
+func (e *Encoder) Encode(violation Violation) error {
+	element, err := json.Marshal(violation)
+	if err != nil {
+		return err
+	}
+
+	separator := ","
+	if e.count == 0 {
+		separator = "["
+	}
+	if _, err := io.WriteString(e.w, separator); err != nil {
+		return err
+	}
+	if _, err := e.w.Write(element); err != nil {
+		return err
+	}
+	e.count++
+	return nil
+}
+
+// Close terminates the array. It must be called once after the last Encode.
+func (e *Encoder) Close() error {
+	end := "]\n"
+	if e.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
+
+// Parse decodes a CodeQuality JSON report from r.
+func Parse(r io.Reader) ([]Violation, error) {
+	var violations []Violation
+
+	decoder := json.NewDecoder(r)
+	if err := decoder.Decode(&violations); err != nil {
+		return nil, err
+	}
+
+	if violations == nil {
+		violations = []Violation{}
+	}
+
+	return violations, nil
+}
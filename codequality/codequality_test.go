@@ -62,6 +62,56 @@ func TestWrite(t *testing.T) {
 		}
 	})
 
+	t.Run("writes content body only when present", func(t *testing.T) {
+		violations := []codequality.Violation{
+			{Fingerprint: "fp1", Content: &codequality.Content{Body: "**User input**: `params[:q]`"}},
+			{Fingerprint: "fp2"},
+		}
+
+		var buf bytes.Buffer
+		err := codequality.Write(violations, &buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		output := buf.String()
+		if strings.Count(output, `"content"`) != 1 {
+			t.Fatalf("expected %q to contain %q once", output, `"content"`)
+		}
+		if !strings.Contains(output, `"body":"**User input**: `) {
+			t.Fatalf("expected %q to contain %q", output, `"body":"**User input**: `)
+		}
+	})
+
+	t.Run("writes positions instead of empty lines", func(t *testing.T) {
+		violations := []codequality.Violation{
+			{
+				Fingerprint: "fp1",
+				Location: codequality.Location{
+					Path: "app/models/user.rb",
+					Positions: &codequality.Positions{
+						Begin: codequality.Position{Line: 42, Column: 3},
+						End:   codequality.Position{Line: 42, Column: 20},
+					},
+				},
+			},
+		}
+
+		var buf bytes.Buffer
+		err := codequality.Write(violations, &buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		output := buf.String()
+		if strings.Contains(output, `"lines"`) {
+			t.Fatalf("expected %q not to contain %q", output, `"lines"`)
+		}
+		if !strings.Contains(output, `"positions":{"begin":{"line":42,"column":3},"end":{"line":42,"column":20}}`) {
+			t.Fatalf("expected %q to contain positions", output)
+		}
+	})
+
 	t.Run("output has no BOM", func(t *testing.T) {
 		violations := []codequality.Violation{}
 
@@ -77,3 +127,85 @@ func TestWrite(t *testing.T) {
 		}
 	})
 }
+
+func TestParse(t *testing.T) {
+	t.Run("parses violations", func(t *testing.T) {
+		input := `[{"description":"Possible SQL injection","check_name":"SQL Injection","fingerprint":"abc123","severity":"critical","location":{"path":"app/models/user.rb","lines":{"begin":42}}}]`
+
+		violations, err := codequality.Parse(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(violations) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(violations))
+		}
+		if violations[0].Fingerprint != "abc123" {
+			t.Fatalf("got %v, want %v", violations[0].Fingerprint, "abc123")
+		}
+		if violations[0].Location.Lines.Begin != 42 {
+			t.Fatalf("got %v, want %v", violations[0].Location.Lines.Begin, 42)
+		}
+	})
+
+	t.Run("returns error for invalid JSON", func(t *testing.T) {
+		_, err := codequality.Parse(strings.NewReader(`{invalid json`))
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+	})
+
+	t.Run("handles null", func(t *testing.T) {
+		violations, err := codequality.Parse(strings.NewReader(`null`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(violations) != 0 {
+			t.Fatalf("expected length %d, got %d", 0, len(violations))
+		}
+	})
+}
+
+func TestEncoder(t *testing.T) {
+	t.Run("produces the same output as Write", func(t *testing.T) {
+		violations := []codequality.Violation{
+			{Description: "Possible <script> injection", Fingerprint: "fp1", Location: codequality.Location{Path: "a.rb", Lines: codequality.Lines{Begin: 1}}},
+			{Description: "Possible SQL injection", Fingerprint: "fp2", Location: codequality.Location{Path: "b.rb", Lines: codequality.Lines{Begin: 2}}},
+		}
+
+		var want bytes.Buffer
+		if err := codequality.Write(violations, &want); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var got bytes.Buffer
+		encoder := codequality.NewEncoder(&got)
+		for _, violation := range violations {
+			if err := encoder.Encode(violation); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if err := encoder.Close(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got.String() != want.String() {
+			t.Fatalf("got %q, want %q", got.String(), want.String())
+		}
+	})
+
+	t.Run("writes empty array", func(t *testing.T) {
+		var want bytes.Buffer
+		if err := codequality.Write([]codequality.Violation{}, &want); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var got bytes.Buffer
+		if err := codequality.NewEncoder(&got).Close(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got.String() != want.String() {
+			t.Fatalf("got %q, want %q", got.String(), want.String())
+		}
+	})
+}
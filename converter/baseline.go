@@ -0,0 +1,98 @@
+package converter
+
+import (
+	"bufio"
+	"io"
+	"unicode"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/codequality"
+)
+
+// Baseline holds the violations of a previous scan, identified by fingerprint.
+type Baseline struct {
+	violations   []codequality.Violation
+	fingerprints map[string]bool
+}
+
+// NewBaseline builds a Baseline from previously reported violations.
+func NewBaseline(violations []codequality.Violation) *Baseline {
+	fingerprints := make(map[string]bool, len(violations))
+	for _, violation := range violations {
+		fingerprints[violation.Fingerprint] = true
+	}
+	return &Baseline{violations: violations, fingerprints: fingerprints}
+}
+
+// Contains reports whether fingerprint was present in the baseline.
+func (b *Baseline) Contains(fingerprint string) bool {
+	return b.fingerprints[fingerprint]
+}
+
+// Fixed returns the baseline violations that are no longer present in current.
+func (b *Baseline) Fixed(current []codequality.Violation) []codequality.Violation {
+	present := make(map[string]bool, len(current))
+	for _, violation := range current {
+		present[violation.Fingerprint] = true
+	}
+
+	fixed := []codequality.Violation{}
+	for _, violation := range b.violations {
+		if !present[violation.Fingerprint] {
+			fixed = append(fixed, violation)
+		}
+	}
+	return fixed
+}
+
+// ParseBaseline reads a previous report from r, which may be either a Brakeman
+// JSON report or a CodeQuality JSON report. A Brakeman report is converted with
+// the policy of c so that its fingerprints are comparable with the current scan.
+func (c *Converter) ParseBaseline(r io.Reader) (*Baseline, error) {
+	previous := *c
+	previous.Baseline = nil
+	return ParseBaselineFunc(r, func(report *brakeman.Report) ([]codequality.Violation, error) {
+		return previous.Warnings(report.Warnings), nil
+	})
+}
+
+// ParseBaselineFunc reads a previous report from r like ParseBaseline,
+// but converts a Brakeman report with convert.
+func ParseBaselineFunc(r io.Reader, convert func(*brakeman.Report) ([]codequality.Violation, error)) (*Baseline, error) {
+	reader := bufio.NewReader(r)
+	first, err := firstByte(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if first == '[' {
+		violations, err := codequality.Parse(reader)
+		if err != nil {
+			return nil, err
+		}
+		return NewBaseline(violations), nil
+	}
+
+	report, err := brakeman.Parse(reader)
+	if err != nil {
+		return nil, err
+	}
+	violations, err := convert(report)
+	if err != nil {
+		return nil, err
+	}
+	return NewBaseline(violations), nil
+}
+
+// firstByte returns the first non-whitespace byte of r without consuming it.
+func firstByte(r *bufio.Reader) (byte, error) {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if !unicode.IsSpace(rune(b)) {
+			return b, r.UnreadByte()
+		}
+	}
+}
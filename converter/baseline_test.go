@@ -0,0 +1,102 @@
+package converter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/codequality"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+)
+
+func TestParseBaseline(t *testing.T) {
+	t.Run("reads a CodeQuality report", func(t *testing.T) {
+		input := ` [{"description":"Possible SQL injection","check_name":"SQL Injection","fingerprint":"fp1","severity":"critical","location":{"path":"app/models/user.rb","lines":{"begin":42}}}]`
+
+		baseline, err := (&converter.Converter{}).ParseBaseline(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !baseline.Contains("fp1") {
+			t.Fatalf("expected true, got false")
+		}
+	})
+
+	t.Run("reads a Brakeman report", func(t *testing.T) {
+		input := "\n{\"warnings\":[{\"warning_type\":\"SQL Injection\",\"message\":\"Possible SQL injection\",\"file\":\"app/models/user.rb\",\"line\":42,\"confidence\":\"High\",\"fingerprint\":\"fp1\"}]}"
+
+		baseline, err := (&converter.Converter{}).ParseBaseline(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !baseline.Contains("fp1") {
+			t.Fatalf("expected true, got false")
+		}
+		if baseline.Contains("fp2") {
+			t.Fatalf("expected false, got true")
+		}
+	})
+
+	t.Run("returns error for invalid JSON", func(t *testing.T) {
+		_, err := (&converter.Converter{}).ParseBaseline(strings.NewReader(`{invalid json`))
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+	})
+
+	t.Run("returns error for empty input", func(t *testing.T) {
+		_, err := (&converter.Converter{}).ParseBaseline(strings.NewReader(""))
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+	})
+}
+
+func TestBaseline(t *testing.T) {
+	previous := []codequality.Violation{
+		{Description: "Possible SQL injection", Fingerprint: "fp1"},
+		{Description: "Unescaped parameter value", Fingerprint: "fp2"},
+	}
+	warnings := []brakeman.Warning{
+		{
+			WarningType: "SQL Injection",
+			Message:     "Possible SQL injection",
+			File:        "app/models/user.rb",
+			Line:        42,
+			Confidence:  "High",
+			Fingerprint: "fp1",
+		},
+		{
+			WarningType: "Redirect",
+			Message:     "Possible unprotected redirect",
+			File:        "app/controllers/users_controller.rb",
+			Line:        7,
+			Confidence:  "High",
+			Fingerprint: "fp3",
+		},
+	}
+
+	t.Run("converter drops warnings present in the baseline", func(t *testing.T) {
+		c := &converter.Converter{Baseline: converter.NewBaseline(previous)}
+
+		violations := c.Warnings(warnings)
+		if len(violations) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(violations))
+		}
+		if violations[0].Fingerprint != "fp3" {
+			t.Fatalf("got %v, want %v", violations[0].Fingerprint, "fp3")
+		}
+	})
+
+	t.Run("lists fixed violations", func(t *testing.T) {
+		baseline := converter.NewBaseline(previous)
+
+		fixed := baseline.Fixed(converter.Warnings(warnings))
+		if len(fixed) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(fixed))
+		}
+		if fixed[0].Fingerprint != "fp2" {
+			t.Fatalf("got %v, want %v", fixed[0].Fingerprint, "fp2")
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package converter
+
+import (
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/checkstyle"
+)
+
+// CheckstyleSeverity maps a CodeQuality severity to a Checkstyle severity.
+func CheckstyleSeverity(severity string) string {
+	switch severity {
+	case "blocker", "critical":
+		return "error"
+	case "major":
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// Source returns the Checkstyle source of a warning, which names the Brakeman check.
+func Source(warning brakeman.Warning) string {
+	return "brakeman." + checkName(warning)
+}
+
+// checkName returns the Brakeman check that produced warning.
+// The warning type stands in for reports without check names.
+func checkName(warning brakeman.Warning) string {
+	if warning.CheckName != "" {
+		return warning.CheckName
+	}
+	return warning.WarningType
+}
+
+// Checkstyle converts a Brakeman report into a Checkstyle report with one file
+// element per path, in order of first appearance.
+func (c *Converter) Checkstyle(report *brakeman.Report) *checkstyle.Report {
+	files := []checkstyle.File{}
+	fileIndex := map[string]int{}
+
+	occurrences := c.occurrences(report.Warnings)
+	for _, warning := range report.Warnings {
+		f, ok := c.finding(warning, occurrences)
+		if !ok {
+			continue
+		}
+
+		index, ok := fileIndex[f.path]
+		if !ok {
+			index = len(files)
+			fileIndex[f.path] = index
+			files = append(files, checkstyle.File{Name: f.path})
+		}
+
+		files[index].Errors = append(files[index].Errors, checkstyle.Error{
+			Line:     warning.Line,
+			Severity: CheckstyleSeverity(f.severity),
+			Message:  f.message,
+			Source:   Source(warning),
+		})
+	}
+
+	return &checkstyle.Report{
+		Version: checkstyle.Version,
+		Files:   files,
+	}
+}
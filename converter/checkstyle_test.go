@@ -0,0 +1,73 @@
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+)
+
+func TestCheckstyleSeverity(t *testing.T) {
+	tests := []struct {
+		severity string
+		want     string
+	}{
+		{severity: "blocker", want: "error"},
+		{severity: "critical", want: "error"},
+		{severity: "major", want: "warning"},
+		{severity: "minor", want: "info"},
+		{severity: "info", want: "info"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.severity, func(t *testing.T) {
+			got := converter.CheckstyleSeverity(tt.severity)
+			if got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckstyle(t *testing.T) {
+	t.Run("groups warnings by file", func(t *testing.T) {
+		report := &brakeman.Report{
+			Warnings: []brakeman.Warning{
+				{WarningType: "SQL Injection", CheckName: "SQL", Message: "Possible SQL injection", File: "./app/models/user.rb", Line: 42, Confidence: "High", Fingerprint: "fp1"},
+				{WarningType: "Cross-Site Scripting", Message: "Unescaped model attribute", File: "app/views/users/show.html.erb", Line: 3, Confidence: "Medium", Fingerprint: "fp2"},
+				{WarningType: "Mass Assignment", CheckName: "ModelAttributes", Message: "Potentially dangerous attribute", File: "app/models/user.rb", Line: 7, Confidence: "Weak", Fingerprint: "fp3"},
+				{WarningType: "Redirect", Message: "Missing line", File: "app/controllers/users_controller.rb", Fingerprint: "fp4"},
+			},
+		}
+
+		got := (&converter.Converter{}).Checkstyle(report)
+
+		if len(got.Files) != 2 {
+			t.Fatalf("got %d files, want 2", len(got.Files))
+		}
+		user := got.Files[0]
+		if user.Name != "app/models/user.rb" {
+			t.Fatalf("got %q, want %q", user.Name, "app/models/user.rb")
+		}
+		if len(user.Errors) != 2 {
+			t.Fatalf("got %d errors, want 2", len(user.Errors))
+		}
+		first := user.Errors[0]
+		if first.Line != 42 || first.Severity != "error" || first.Message != "Possible SQL injection" || first.Source != "brakeman.SQL" {
+			t.Fatalf("got %+v", first)
+		}
+		if user.Errors[1].Severity != "info" {
+			t.Fatalf("got %q, want %q", user.Errors[1].Severity, "info")
+		}
+		if source := got.Files[1].Errors[0].Source; source != "brakeman.Cross-Site Scripting" {
+			t.Fatalf("got %q, want %q", source, "brakeman.Cross-Site Scripting")
+		}
+	})
+
+	t.Run("produces an empty report without warnings", func(t *testing.T) {
+		got := (&converter.Converter{}).Checkstyle(&brakeman.Report{})
+		if got.Files == nil || len(got.Files) != 0 {
+			t.Fatalf("got %v, want empty files", got.Files)
+		}
+	})
+}
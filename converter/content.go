@@ -0,0 +1,58 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+)
+
+// Body renders the details of warning as Markdown: the code snippet, the user
+// input involved, where the warning was raised, the CWE and the Brakeman documentation.
+// It returns an empty string when the warning carries none of them.
+func Body(warning brakeman.Warning) string {
+	var sections []string
+
+	if warning.Code != "" {
+		fence := "```"
+		for strings.Contains(warning.Code, fence) {
+			fence += "`"
+		}
+		sections = append(sections, fmt.Sprintf("**Code**\n\n%sruby\n%s\n%s", fence, warning.Code, fence))
+	}
+	if warning.UserInput != "" {
+		sections = append(sections, fmt.Sprintf("**User input**: `%s`", warning.UserInput))
+	}
+	if location := describeLocation(warning.Location); location != "" {
+		sections = append(sections, "**Location**: "+location)
+	}
+	if len(warning.CWEID) > 0 {
+		links := make([]string, 0, len(warning.CWEID))
+		for _, cwe := range warning.CWEID {
+			links = append(links, fmt.Sprintf("[CWE-%d](https://cwe.mitre.org/data/definitions/%d.html)", cwe, cwe))
+		}
+		sections = append(sections, "**CWE**: "+strings.Join(links, ", "))
+	}
+	if warning.Link != "" {
+		sections = append(sections, fmt.Sprintf("**Documentation**: [%s](%s)", warning.WarningType, warning.Link))
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+func describeLocation(location *brakeman.Location) string {
+	if location == nil {
+		return ""
+	}
+
+	switch {
+	case location.Template != "":
+		return fmt.Sprintf("template `%s`", location.Template)
+	case location.Class != "" && location.Method != "":
+		return fmt.Sprintf("method `%s#%s`", location.Class, location.Method)
+	case location.Class != "":
+		return fmt.Sprintf("%s `%s`", location.Type, location.Class)
+	default:
+		return ""
+	}
+}
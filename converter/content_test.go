@@ -0,0 +1,88 @@
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+)
+
+func TestBody(t *testing.T) {
+	t.Run("renders every available detail", func(t *testing.T) {
+		warning := brakeman.Warning{
+			WarningType: "SQL Injection",
+			Code:        "User.where(params[:q])",
+			UserInput:   "params[:q]",
+			Location:    &brakeman.Location{Type: "method", Class: "User", Method: "search"},
+			CWEID:       []int{89},
+			Link:        "https://brakemanscanner.org/docs/warning_types/sql_injection/",
+		}
+
+		want := "**Code**\n\n```ruby\nUser.where(params[:q])\n```\n\n" +
+			"**User input**: `params[:q]`\n\n" +
+			"**Location**: method `User#search`\n\n" +
+			"**CWE**: [CWE-89](https://cwe.mitre.org/data/definitions/89.html)\n\n" +
+			"**Documentation**: [SQL Injection](https://brakemanscanner.org/docs/warning_types/sql_injection/)"
+
+		got := converter.Body(warning)
+		if got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("renders template location", func(t *testing.T) {
+		warning := brakeman.Warning{
+			Location: &brakeman.Location{Type: "template", Template: "users/show"},
+		}
+
+		got := converter.Body(warning)
+		if got != "**Location**: template `users/show`" {
+			t.Fatalf("got %q, want %q", got, "**Location**: template `users/show`")
+		}
+	})
+
+	t.Run("lengthens the fence when code contains backticks", func(t *testing.T) {
+		warning := brakeman.Warning{Code: "x = ```"}
+
+		got := converter.Body(warning)
+		if got != "**Code**\n\n````ruby\nx = ```\n````" {
+			t.Fatalf("got %q", got)
+		}
+	})
+
+	t.Run("returns empty string without details", func(t *testing.T) {
+		got := converter.Body(brakeman.Warning{WarningType: "SQL Injection"})
+		if got != "" {
+			t.Fatalf("expected empty string, got %q", got)
+		}
+	})
+
+	t.Run("is attached to violations", func(t *testing.T) {
+		violations := converter.Warnings([]brakeman.Warning{
+			{
+				WarningType: "SQL Injection",
+				Message:     "Possible SQL injection",
+				File:        "app/models/user.rb",
+				Line:        42,
+				Confidence:  "High",
+				UserInput:   "params[:q]",
+				Fingerprint: "fp1",
+			},
+			{
+				WarningType: "SQL Injection",
+				Message:     "Possible SQL injection",
+				File:        "app/models/user.rb",
+				Line:        50,
+				Confidence:  "High",
+				Fingerprint: "fp2",
+			},
+		})
+
+		if violations[0].Content == nil || violations[0].Content.Body != "**User input**: `params[:q]`" {
+			t.Fatalf("unexpected content: %+v", violations[0].Content)
+		}
+		if violations[1].Content != nil {
+			t.Fatalf("expected nil, got %+v", violations[1].Content)
+		}
+	})
+}
@@ -7,6 +7,48 @@ import (
 	"github.com/Omochice/brakeman-to-codequality/codequality"
 )
 
+// IgnoreAction selects what happens to warnings listed in a brakeman.ignore file.
+type IgnoreAction string
+
+const (
+	// IgnoreDrop removes ignored warnings from the output.
+	IgnoreDrop IgnoreAction = "drop"
+	// IgnoreDowngrade keeps ignored warnings with info severity and the triage note appended.
+	IgnoreDowngrade IgnoreAction = "downgrade"
+)
+
+// remediationPoints is the baseline effort the Code Climate specification suggests for a single issue.
+const remediationPoints = 50000
+
+// Converter maps Brakeman warnings to the supported output formats.
+// The zero value converts every valid warning with the default severity mapping.
+// Every conversion leaves out warnings that lack a file, line, warning type, or
+// message, and warnings without a Brakeman fingerprint when FingerprintBrakeman
+// is used, as well as those dropped by Ignore or Baseline.
+type Converter struct {
+	// Severities overrides the default confidence based severity mapping.
+	Severities *SeverityConfig
+	// Ignore lists warnings triaged as false positives. Nil disables ignoring.
+	Ignore *brakeman.Ignore
+	// IgnoreAction applies to warnings found in Ignore. The default is IgnoreDrop.
+	IgnoreAction IgnoreAction
+	// Fingerprint selects how fingerprints are produced. The default is FingerprintBrakeman.
+	Fingerprint FingerprintMode
+	// Paths rewrites every reported location.
+	Paths PathRules
+	// Baseline drops warnings already reported by a previous scan. Nil keeps every warning.
+	Baseline *Baseline
+}
+
+// finding is a valid warning with the policy of a Converter applied.
+type finding struct {
+	warning     brakeman.Warning
+	path        string
+	fingerprint string
+	message     string
+	severity    string
+}
+
 // Severity maps a Brakeman confidence level to a CodeQuality severity.
 func Severity(confidence string) string {
 	switch strings.ToLower(confidence) {
@@ -21,33 +63,140 @@ func Severity(confidence string) string {
 	}
 }
 
-// Warnings converts Brakeman warnings into CodeQuality violations.
-// Warnings that lack a file, line, warning type, message, or fingerprint are skipped.
+// Warnings converts Brakeman warnings into CodeQuality violations using the default policy.
 func Warnings(warnings []brakeman.Warning) []codequality.Violation {
+	return (&Converter{}).Warnings(warnings)
+}
+
+// Warnings converts Brakeman warnings into CodeQuality violations.
+func (c *Converter) Warnings(warnings []brakeman.Warning) []codequality.Violation {
 	violations := make([]codequality.Violation, 0, len(warnings))
 
+	occurrences := c.occurrences(warnings)
 	for _, warning := range warnings {
-		if warning.File == "" || warning.Line == 0 || warning.WarningType == "" || warning.Message == "" || warning.Fingerprint == "" {
-			continue
+		if violation, ok := c.violation(warning, occurrences); ok {
+			violations = append(violations, violation)
 		}
+	}
+
+	return violations
+}
+
+// Warning converts a single Brakeman warning into a CodeQuality violation.
+// It reports false when the warning is left out, as described for Converter.
+// Identical findings are told apart only by Warnings, which sees all of them,
+// so Warning does not suit FingerprintPathNormalized.
+func (c *Converter) Warning(warning brakeman.Warning) (codequality.Violation, bool) {
+	return c.violation(warning, nil)
+}
+
+func (c *Converter) violation(warning brakeman.Warning, occurrences occurrences) (codequality.Violation, bool) {
+	f, ok := c.finding(warning, occurrences)
+	if !ok {
+		return codequality.Violation{}, false
+	}
 
-		path := strings.TrimPrefix(warning.File, "./")
-
-		violation := codequality.Violation{
-			Description: warning.Message,
-			CheckName:   warning.WarningType,
-			Fingerprint: warning.Fingerprint,
-			Severity:    Severity(warning.Confidence),
-			Location: codequality.Location{
-				Path: path,
-				Lines: codequality.Lines{
-					Begin: warning.Line,
-				},
+	violation := codequality.Violation{
+		Type:              "issue",
+		Description:       f.message,
+		CheckName:         warning.WarningType,
+		Fingerprint:       f.fingerprint,
+		Severity:          f.severity,
+		Categories:        []string{"Security"},
+		EngineName:        "brakeman",
+		RemediationPoints: remediationPoints,
+		Location: codequality.Location{
+			Path: f.path,
+			Lines: codequality.Lines{
+				Begin: warning.Line,
+				End:   warning.Line,
 			},
+		},
+		OtherLocations: c.otherLocations(warning),
+	}
+	if body := Body(warning); body != "" {
+		violation.Content = &codequality.Content{Body: body}
+	}
+
+	return violation, true
+}
+
+// finding validates warning and applies the ignore and baseline policies.
+// It reports false when the warning must not appear in the output.
+func (c *Converter) finding(warning brakeman.Warning, occurrences occurrences) (finding, bool) {
+	if len(c.missing(warning)) > 0 {
+		return finding{}, false
+	}
+
+	path := c.Paths.Apply(warning.File)
+	f := finding{
+		warning:     warning,
+		path:        path,
+		fingerprint: c.fingerprint(warning, path, occurrences),
+		message:     warning.Message,
+		severity:    c.severity(warning),
+	}
+	if c.Baseline != nil && c.Baseline.Contains(f.fingerprint) {
+		return finding{}, false
+	}
+
+	if c.Ignore != nil && warning.Fingerprint != "" {
+		if note, ok := c.Ignore.Lookup(warning.Fingerprint); ok {
+			if c.IgnoreAction != IgnoreDowngrade {
+				return finding{}, false
+			}
+			f.severity = "info"
+			if note != "" {
+				f.message += " (ignored: " + note + ")"
+			}
 		}
+	}
+
+	return f, true
+}
 
-		violations = append(violations, violation)
+func (c *Converter) severity(warning brakeman.Warning) string {
+	if c.Severities != nil {
+		return c.Severities.Severity(warning)
 	}
+	return Severity(string(warning.Confidence))
+}
 
-	return violations
+// missing lists the fields warning lacks that the output formats require.
+func (c *Converter) missing(warning brakeman.Warning) []string {
+	var fields []string
+	if warning.File == "" {
+		fields = append(fields, "file")
+	}
+	if warning.Line == 0 {
+		fields = append(fields, "line")
+	}
+	if warning.WarningType == "" {
+		fields = append(fields, "warning_type")
+	}
+	if warning.Message == "" {
+		fields = append(fields, "message")
+	}
+	if warning.Fingerprint == "" && (c.Fingerprint == "" || c.Fingerprint == FingerprintBrakeman) {
+		fields = append(fields, "fingerprint")
+	}
+	return fields
+}
+
+// otherLocations lists the steps of the render path that led to warning.
+func (c *Converter) otherLocations(warning brakeman.Warning) []codequality.Location {
+	var locations []codequality.Location
+	for _, entry := range warning.RenderPath {
+		if entry.File == "" || entry.Line == 0 {
+			continue
+		}
+		locations = append(locations, codequality.Location{
+			Path: c.Paths.Apply(entry.File),
+			Lines: codequality.Lines{
+				Begin: entry.Line,
+				End:   entry.Line,
+			},
+		})
+	}
+	return locations
 }
@@ -125,6 +125,47 @@ func TestWarnings(t *testing.T) {
 		}
 	})
 
+	t.Run("fills Code Climate issue fields", func(t *testing.T) {
+		warnings := []brakeman.Warning{
+			{
+				WarningType: "Cross-Site Scripting",
+				Message:     "Unescaped parameter value",
+				File:        "app/views/users/show.html.erb",
+				Line:        3,
+				Confidence:  "High",
+				RenderPath: []brakeman.RenderPathEntry{
+					{Type: "controller", Class: "UsersController", Method: "show", Line: 12, File: "./app/controllers/users_controller.rb"},
+					{Type: "template", Name: "users/show", Line: 0, File: "app/views/users/show.html.erb"},
+				},
+				Fingerprint: "fp1",
+			},
+		}
+
+		violations := converter.Warnings(warnings)
+		violation := violations[0]
+		if violation.Type != "issue" {
+			t.Fatalf("got %v, want %v", violation.Type, "issue")
+		}
+		if len(violation.Categories) != 1 || violation.Categories[0] != "Security" {
+			t.Fatalf("got %v, want %v", violation.Categories, []string{"Security"})
+		}
+		if violation.EngineName != "brakeman" {
+			t.Fatalf("got %v, want %v", violation.EngineName, "brakeman")
+		}
+		if violation.RemediationPoints <= 0 {
+			t.Fatalf("expected positive remediation points, got %v", violation.RemediationPoints)
+		}
+		if violation.Location.Lines.End != 3 {
+			t.Fatalf("got %v, want %v", violation.Location.Lines.End, 3)
+		}
+		if len(violation.OtherLocations) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(violation.OtherLocations))
+		}
+		if violation.OtherLocations[0].Path != "app/controllers/users_controller.rb" || violation.OtherLocations[0].Lines.Begin != 12 {
+			t.Fatalf("unexpected other location: %+v", violation.OtherLocations[0])
+		}
+	})
+
 	t.Run("skips warning with missing file", func(t *testing.T) {
 		warnings := []brakeman.Warning{
 			{
@@ -304,3 +345,68 @@ func TestWarnings(t *testing.T) {
 		}
 	})
 }
+
+func TestConverterIgnore(t *testing.T) {
+	warnings := []brakeman.Warning{
+		{
+			WarningType: "SQL Injection",
+			Message:     "Possible SQL injection",
+			File:        "app/models/user.rb",
+			Line:        42,
+			Confidence:  "High",
+			Fingerprint: "fp1",
+		},
+		{
+			WarningType: "XSS",
+			Message:     "Possible XSS vulnerability",
+			File:        "app/views/users/show.html.erb",
+			Line:        10,
+			Confidence:  "Medium",
+			Fingerprint: "fp2",
+		},
+	}
+	ignore := &brakeman.Ignore{
+		IgnoredWarnings: []brakeman.IgnoredWarning{
+			{Warning: brakeman.Warning{Fingerprint: "fp1"}, Note: "Sanitized upstream"},
+		},
+	}
+
+	t.Run("drops ignored warnings by default", func(t *testing.T) {
+		c := &converter.Converter{Ignore: ignore}
+
+		violations := c.Warnings(warnings)
+		if len(violations) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(violations))
+		}
+		if violations[0].Fingerprint != "fp2" {
+			t.Fatalf("got %v, want %v", violations[0].Fingerprint, "fp2")
+		}
+	})
+
+	t.Run("downgrades ignored warnings to info", func(t *testing.T) {
+		c := &converter.Converter{Ignore: ignore, IgnoreAction: converter.IgnoreDowngrade}
+
+		violations := c.Warnings(warnings)
+		if len(violations) != 2 {
+			t.Fatalf("expected length %d, got %d", 2, len(violations))
+		}
+		if violations[0].Severity != "info" {
+			t.Fatalf("got %v, want %v", violations[0].Severity, "info")
+		}
+		if violations[0].Description != "Possible SQL injection (ignored: Sanitized upstream)" {
+			t.Fatalf("got %v, want %v", violations[0].Description, "Possible SQL injection (ignored: Sanitized upstream)")
+		}
+		if violations[1].Severity != "major" {
+			t.Fatalf("got %v, want %v", violations[1].Severity, "major")
+		}
+	})
+
+	t.Run("applies to other formats", func(t *testing.T) {
+		c := &converter.Converter{Ignore: ignore}
+
+		log := c.SARIF(&brakeman.Report{Warnings: warnings})
+		if len(log.Runs[0].Results) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(log.Runs[0].Results))
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package converter
+
+import (
+	"path"
+	"path/filepath"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/codequality"
+)
+
+// DefaultIgnoreFile is where Brakeman looks for its ignore file within an application.
+const DefaultIgnoreFile = "config/brakeman.ignore"
+
+// Errors converts the errors Brakeman ran into, such as unparsable templates,
+// into info violations on the affected file. Errors that do not name a file,
+// such as internal failures of a check, are skipped.
+func (c *Converter) Errors(errors []brakeman.Error) []codequality.Violation {
+	violations := make([]codequality.Violation, 0, len(errors))
+
+	for _, e := range errors {
+		file := e.File()
+		if file == "" {
+			continue
+		}
+
+		path := c.Paths.Apply(file)
+		violation := diagnostic(path, "Brakeman Error", "Brakeman could not process this file: "+e.Error, "info", "Bug Risk", hash("error", path, e.Error))
+		if c.Baseline != nil && c.Baseline.Contains(violation.Fingerprint) {
+			continue
+		}
+		violations = append(violations, violation)
+	}
+
+	return violations
+}
+
+// Obsolete converts ignore entries that no longer match any warning into
+// minor violations on the ignore file at ignoreFile, a path relative to the
+// working directory. An empty ignoreFile means DefaultIgnoreFile within the
+// AppRoot of each entry, which goes through the path rules.
+func (c *Converter) Obsolete(entries []brakeman.Obsolete, ignoreFile string) []codequality.Violation {
+	violations := make([]codequality.Violation, 0, len(entries))
+
+	for _, entry := range entries {
+		file := c.Paths.Apply(path.Join(entry.AppRoot, DefaultIgnoreFile))
+		if ignoreFile != "" {
+			file = filepath.ToSlash(filepath.Clean(ignoreFile))
+		}
+
+		description := "Obsolete ignore entry: fingerprint " + entry.Fingerprint + " no longer matches any warning"
+		violation := diagnostic(file, "Brakeman Obsolete Ignore", description, "minor", "Clarity", hash("obsolete", file, entry.Fingerprint))
+		if c.Baseline != nil && c.Baseline.Contains(violation.Fingerprint) {
+			continue
+		}
+		violations = append(violations, violation)
+	}
+
+	return violations
+}
+
+// diagnostic builds a violation about a file as a whole, reported on its first line.
+func diagnostic(path, checkName, description, severity, category, fingerprint string) codequality.Violation {
+	return codequality.Violation{
+		Type:        "issue",
+		Description: description,
+		CheckName:   checkName,
+		Fingerprint: fingerprint,
+		Severity:    severity,
+		Categories:  []string{category},
+		EngineName:  "brakeman",
+		Location: codequality.Location{
+			Path: path,
+			Lines: codequality.Lines{
+				Begin: 1,
+				End:   1,
+			},
+		},
+	}
+}
@@ -0,0 +1,92 @@
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+)
+
+func TestErrors(t *testing.T) {
+	t.Run("converts parse errors into info violations", func(t *testing.T) {
+		c := &converter.Converter{Paths: converter.PathRules{Root: "/app"}}
+
+		violations := c.Errors([]brakeman.Error{
+			{Error: "syntax error, unexpected end-of-input Could not parse /app/app/views/users/edit.html.erb", Location: "/usr/local/bundle/gems/ruby_parser-3.21.0/lib/ruby_parser_extras.rb:1218:in 'on_error'"},
+			{Error: "undefined method 'each' for nil", Location: "/usr/local/bundle/gems/brakeman-6.1.0/lib/brakeman/checks/check_sql.rb:42:in 'process'"},
+		})
+		if len(violations) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(violations))
+		}
+
+		violation := violations[0]
+		if violation.Severity != "info" {
+			t.Fatalf("got %v, want %v", violation.Severity, "info")
+		}
+		if violation.Location.Path != "app/views/users/edit.html.erb" {
+			t.Fatalf("got %v, want %v", violation.Location.Path, "app/views/users/edit.html.erb")
+		}
+		if violation.Location.Lines.Begin != 1 {
+			t.Fatalf("got %v, want %v", violation.Location.Lines.Begin, 1)
+		}
+		if violation.Description != "Brakeman could not process this file: syntax error, unexpected end-of-input Could not parse /app/app/views/users/edit.html.erb" {
+			t.Fatalf("got %v", violation.Description)
+		}
+		if len(violation.Fingerprint) != 64 {
+			t.Fatalf("expected a SHA-256 fingerprint, got %v", violation.Fingerprint)
+		}
+	})
+}
+
+func TestObsolete(t *testing.T) {
+	t.Run("converts obsolete entries into violations on the ignore file", func(t *testing.T) {
+		c := &converter.Converter{}
+
+		violations := c.Obsolete([]brakeman.Obsolete{{Fingerprint: "abc123"}, {Fingerprint: "def456"}}, "")
+		if len(violations) != 2 {
+			t.Fatalf("expected length %d, got %d", 2, len(violations))
+		}
+		if violations[0].Location.Path != "config/brakeman.ignore" {
+			t.Fatalf("got %v, want %v", violations[0].Location.Path, "config/brakeman.ignore")
+		}
+		if violations[0].Severity != "minor" {
+			t.Fatalf("got %v, want %v", violations[0].Severity, "minor")
+		}
+		if violations[0].Fingerprint == violations[1].Fingerprint {
+			t.Fatalf("expected fingerprints to differ")
+		}
+	})
+
+	t.Run("rewrites only the default ignore file", func(t *testing.T) {
+		c := &converter.Converter{Paths: converter.PathRules{Prefix: "services/api"}}
+
+		if got := c.Obsolete([]brakeman.Obsolete{{Fingerprint: "abc123"}}, "")[0].Location.Path; got != "services/api/config/brakeman.ignore" {
+			t.Fatalf("got %v, want %v", got, "services/api/config/brakeman.ignore")
+		}
+		if got := c.Obsolete([]brakeman.Obsolete{{Fingerprint: "abc123"}}, "./services/api/config/brakeman.ignore")[0].Location.Path; got != "services/api/config/brakeman.ignore" {
+			t.Fatalf("got %v, want %v", got, "services/api/config/brakeman.ignore")
+		}
+	})
+
+	t.Run("reports entries on the ignore file of their application", func(t *testing.T) {
+		c := &converter.Converter{}
+
+		violations := c.Obsolete([]brakeman.Obsolete{{Fingerprint: "abc123", AppRoot: "engines/a"}, {Fingerprint: "abc123", AppRoot: "engines/b"}}, "")
+		if violations[0].Location.Path != "engines/a/config/brakeman.ignore" || violations[1].Location.Path != "engines/b/config/brakeman.ignore" {
+			t.Fatalf("got %v and %v", violations[0].Location.Path, violations[1].Location.Path)
+		}
+		if violations[0].Fingerprint == violations[1].Fingerprint {
+			t.Fatalf("expected fingerprints to differ")
+		}
+	})
+
+	t.Run("drops entries present in the baseline", func(t *testing.T) {
+		previous := (&converter.Converter{}).Obsolete([]brakeman.Obsolete{{Fingerprint: "abc123"}}, "")
+		c := &converter.Converter{Baseline: converter.NewBaseline(previous)}
+
+		violations := c.Obsolete([]brakeman.Obsolete{{Fingerprint: "abc123"}, {Fingerprint: "def456"}}, "")
+		if len(violations) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(violations))
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package converter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+)
+
+// FingerprintMode selects how violation fingerprints are produced.
+type FingerprintMode string
+
+const (
+	// FingerprintBrakeman passes the fingerprint computed by Brakeman through,
+	// hashed with the application root of rebased reports.
+	// Warnings without one are skipped. This is the default.
+	FingerprintBrakeman FingerprintMode = "brakeman"
+	// FingerprintComputed hashes the path, line, warning type, message and code.
+	FingerprintComputed FingerprintMode = "computed"
+	// FingerprintPathNormalized hashes the same fields except the line,
+	// so that findings keep their identity when surrounding code shifts.
+	// Identical findings in one file are numbered by line to keep them apart.
+	FingerprintPathNormalized FingerprintMode = "path-normalized"
+)
+
+// Fingerprint computes the SHA-256 fingerprint of warning located at path.
+// The line is left out when withLine is false.
+func Fingerprint(warning brakeman.Warning, path string, withLine bool) string {
+	fields := []string{path, warning.WarningType, warning.Message, warning.Code}
+	if withLine {
+		fields = append(fields, strconv.Itoa(warning.Line))
+	}
+
+	return hash(fields...)
+}
+
+// hash returns the hex encoded SHA-256 digest of fields.
+func hash(fields ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// occurrences maps the path-normalized fingerprint shared by identical
+// findings to the distinct lines they are found on, in file order.
+type occurrences map[string][]int
+
+// occurrences collects the lines of identical findings among warnings.
+// It returns nil unless FingerprintPathNormalized is used.
+func (c *Converter) occurrences(warnings []brakeman.Warning) occurrences {
+	if c.Fingerprint != FingerprintPathNormalized {
+		return nil
+	}
+
+	lines := occurrences{}
+	for _, warning := range warnings {
+		if len(c.missing(warning)) > 0 {
+			continue
+		}
+		key := Fingerprint(warning, c.Paths.Apply(warning.File), false)
+		lines[key] = append(lines[key], warning.Line)
+	}
+	for key := range lines {
+		slices.Sort(lines[key])
+		lines[key] = slices.Compact(lines[key])
+	}
+	return lines
+}
+
+// index returns the position of line among the findings sharing key.
+func (o occurrences) index(key string, line int) int {
+	i, _ := slices.BinarySearch(o[key], line)
+	return i
+}
+
+// fingerprint returns the fingerprint of warning according to c.Fingerprint.
+// The first of identical path-normalized findings keeps the plain hash.
+func (c *Converter) fingerprint(warning brakeman.Warning, path string, occurrences occurrences) string {
+	switch c.Fingerprint {
+	case FingerprintComputed:
+		return Fingerprint(warning, path, true)
+	case FingerprintPathNormalized:
+		fingerprint := Fingerprint(warning, path, false)
+		if i := occurrences.index(fingerprint, warning.Line); i > 0 {
+			return hash(fingerprint, strconv.Itoa(i))
+		}
+		return fingerprint
+	default:
+		if warning.AppRoot != "" {
+			return hash(warning.AppRoot, warning.Fingerprint)
+		}
+		return warning.Fingerprint
+	}
+}
@@ -0,0 +1,139 @@
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+)
+
+func TestFingerprint(t *testing.T) {
+	warning := brakeman.Warning{
+		WarningType: "SQL Injection",
+		Message:     "Possible SQL injection",
+		File:        "app/models/user.rb",
+		Line:        42,
+		Code:        "User.where(params[:q])",
+	}
+	moved := warning
+	moved.Line = 50
+
+	t.Run("is a SHA-256 hex digest", func(t *testing.T) {
+		got := converter.Fingerprint(warning, "app/models/user.rb", true)
+		if len(got) != 64 {
+			t.Fatalf("expected length %d, got %d", 64, len(got))
+		}
+		if got != converter.Fingerprint(warning, "app/models/user.rb", true) {
+			t.Fatalf("expected fingerprint to be stable")
+		}
+	})
+
+	t.Run("depends on the line", func(t *testing.T) {
+		if converter.Fingerprint(warning, "app/models/user.rb", true) == converter.Fingerprint(moved, "app/models/user.rb", true) {
+			t.Fatalf("expected fingerprints to differ")
+		}
+	})
+
+	t.Run("ignores the line when asked", func(t *testing.T) {
+		if converter.Fingerprint(warning, "app/models/user.rb", false) != converter.Fingerprint(moved, "app/models/user.rb", false) {
+			t.Fatalf("expected fingerprints to match")
+		}
+	})
+
+	t.Run("depends on the path", func(t *testing.T) {
+		if converter.Fingerprint(warning, "app/models/user.rb", false) == converter.Fingerprint(warning, "engines/api/app/models/user.rb", false) {
+			t.Fatalf("expected fingerprints to differ")
+		}
+	})
+}
+
+func TestConverterFingerprint(t *testing.T) {
+	warnings := []brakeman.Warning{
+		{
+			WarningType: "SQL Injection",
+			Message:     "Possible SQL injection",
+			File:        "./app/models/user.rb",
+			Line:        42,
+			Confidence:  "High",
+		},
+	}
+
+	t.Run("brakeman mode skips warnings without fingerprint", func(t *testing.T) {
+		c := &converter.Converter{Fingerprint: converter.FingerprintBrakeman}
+		if violations := c.Warnings(warnings); len(violations) != 0 {
+			t.Fatalf("expected length %d, got %d", 0, len(violations))
+		}
+	})
+
+	t.Run("brakeman mode mixes the application root into the fingerprint", func(t *testing.T) {
+		c := &converter.Converter{Fingerprint: converter.FingerprintBrakeman}
+		rebased := []brakeman.Warning{warnings[0], warnings[0], warnings[0]}
+		rebased[0].Fingerprint = "fp1"
+		rebased[1].Fingerprint, rebased[1].AppRoot = "fp1", "engines/a"
+		rebased[2].Fingerprint, rebased[2].AppRoot = "fp1", "engines/b"
+
+		violations := c.Warnings(rebased)
+		if violations[0].Fingerprint != "fp1" {
+			t.Fatalf("got %v, want %v", violations[0].Fingerprint, "fp1")
+		}
+		if violations[1].Fingerprint == "fp1" || violations[1].Fingerprint == violations[2].Fingerprint {
+			t.Fatalf("expected distinct fingerprints per application root, got %v and %v", violations[1].Fingerprint, violations[2].Fingerprint)
+		}
+	})
+
+	t.Run("computed mode keeps warnings without fingerprint", func(t *testing.T) {
+		c := &converter.Converter{Fingerprint: converter.FingerprintComputed}
+
+		violations := c.Warnings(warnings)
+		if len(violations) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(violations))
+		}
+		want := converter.Fingerprint(warnings[0], "app/models/user.rb", true)
+		if violations[0].Fingerprint != want {
+			t.Fatalf("got %v, want %v", violations[0].Fingerprint, want)
+		}
+	})
+
+	t.Run("path-normalized mode leaves out the line", func(t *testing.T) {
+		c := &converter.Converter{Fingerprint: converter.FingerprintPathNormalized}
+
+		violations := c.Warnings(warnings)
+		want := converter.Fingerprint(warnings[0], "app/models/user.rb", false)
+		if violations[0].Fingerprint != want {
+			t.Fatalf("got %v, want %v", violations[0].Fingerprint, want)
+		}
+	})
+
+	t.Run("path-normalized mode tells identical findings of one file apart", func(t *testing.T) {
+		c := &converter.Converter{Fingerprint: converter.FingerprintPathNormalized}
+		first := brakeman.Warning{WarningType: "SQL Injection", Message: "Possible SQL injection", File: "app/models/user.rb", Line: 10, Code: "User.where(params[:q])", Confidence: "High"}
+		second := first
+		second.Line = 20
+
+		violations := c.Warnings([]brakeman.Warning{second, first})
+		if violations[0].Fingerprint == violations[1].Fingerprint {
+			t.Fatalf("expected distinct fingerprints, got %v twice", violations[0].Fingerprint)
+		}
+		if violations[1].Fingerprint != converter.Fingerprint(first, "app/models/user.rb", false) {
+			t.Fatalf("expected the first occurrence in the file to keep the plain hash, got %v", violations[1].Fingerprint)
+		}
+
+		log := c.SARIF(&brakeman.Report{Warnings: []brakeman.Warning{first, second}})
+		if log.Runs[0].Results[1].PartialFingerprints["path-normalized/v1"] != violations[0].Fingerprint {
+			t.Fatalf("expected SARIF to number occurrences the same way, got %v", log.Runs[0].Results[1].PartialFingerprints)
+		}
+	})
+
+	t.Run("computed fingerprint is used in SARIF", func(t *testing.T) {
+		c := &converter.Converter{Fingerprint: converter.FingerprintComputed}
+
+		log := c.SARIF(&brakeman.Report{Warnings: warnings})
+		fingerprints := log.Runs[0].Results[0].PartialFingerprints
+		if _, ok := fingerprints["brakeman/v1"]; ok {
+			t.Fatalf("expected no Brakeman fingerprint, got %v", fingerprints)
+		}
+		if fingerprints["computed/v1"] != converter.Fingerprint(warnings[0], "app/models/user.rb", true) {
+			t.Fatalf("unexpected fingerprints: %v", fingerprints)
+		}
+	})
+}
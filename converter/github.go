@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/github"
+)
+
+// AnnotationCommand maps a CodeQuality severity to a GitHub Actions annotation command.
+func AnnotationCommand(severity string) string {
+	switch severity {
+	case "blocker", "critical":
+		return "error"
+	case "major":
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+// Annotations converts a Brakeman report into GitHub Actions annotations,
+// titled with the warning type.
+func (c *Converter) Annotations(report *brakeman.Report) []github.Annotation {
+	annotations := make([]github.Annotation, 0, len(report.Warnings))
+
+	occurrences := c.occurrences(report.Warnings)
+	for _, warning := range report.Warnings {
+		f, ok := c.finding(warning, occurrences)
+		if !ok {
+			continue
+		}
+
+		annotations = append(annotations, github.Annotation{
+			Command: AnnotationCommand(f.severity),
+			File:    f.path,
+			Line:    warning.Line,
+			Title:   warning.WarningType,
+			Message: f.message,
+		})
+	}
+
+	return annotations
+}
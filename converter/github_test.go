@@ -0,0 +1,58 @@
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+	"github.com/Omochice/brakeman-to-codequality/github"
+)
+
+func TestAnnotationCommand(t *testing.T) {
+	tests := []struct {
+		severity string
+		want     string
+	}{
+		{severity: "blocker", want: "error"},
+		{severity: "critical", want: "error"},
+		{severity: "major", want: "warning"},
+		{severity: "minor", want: "notice"},
+		{severity: "info", want: "notice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.severity, func(t *testing.T) {
+			got := converter.AnnotationCommand(tt.severity)
+			if got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnotations(t *testing.T) {
+	t.Run("converts valid warnings into annotations", func(t *testing.T) {
+		report := &brakeman.Report{
+			Warnings: []brakeman.Warning{
+				{WarningType: "SQL Injection", Message: "Possible SQL injection", File: "./app/models/user.rb", Line: 42, Confidence: "High", Fingerprint: "fp1"},
+				{WarningType: "Redirect", Message: "Missing line", File: "app/controllers/users_controller.rb", Fingerprint: "fp2"},
+				{WarningType: "Cross-Site Scripting", Message: "Unescaped model attribute", File: "app/views/users/show.html.erb", Line: 3, Confidence: "Medium", Fingerprint: "fp3"},
+			},
+		}
+
+		got := (&converter.Converter{}).Annotations(report)
+
+		want := []github.Annotation{
+			{Command: "error", File: "app/models/user.rb", Line: 42, Title: "SQL Injection", Message: "Possible SQL injection"},
+			{Command: "warning", File: "app/views/users/show.html.erb", Line: 3, Title: "Cross-Site Scripting", Message: "Unescaped model attribute"},
+		}
+		if len(got) != len(want) {
+			t.Fatalf("got %d annotations, want %d", len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("got %+v, want %+v", got[i], want[i])
+			}
+		}
+	})
+}
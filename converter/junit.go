@@ -0,0 +1,76 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/junit"
+)
+
+// JUnit converts a Brakeman report into a JUnit report with one test suite per
+// check. Each warning is a failing test case, and each check listed in
+// checks_performed that reported nothing is a single passing test case.
+func (c *Converter) JUnit(report *brakeman.Report) *junit.Report {
+	suites := []junit.TestSuite{}
+	suiteIndex := map[string]int{}
+	failures := 0
+
+	occurrences := c.occurrences(report.Warnings)
+	for _, warning := range report.Warnings {
+		f, ok := c.finding(warning, occurrences)
+		if !ok {
+			continue
+		}
+
+		check := checkName(warning)
+		index, ok := suiteIndex[check]
+		if !ok {
+			index = len(suites)
+			suiteIndex[check] = index
+			suites = append(suites, junit.TestSuite{Name: check})
+		}
+
+		location := fmt.Sprintf("%s:%d", f.path, warning.Line)
+		body := location + "\n" + f.message
+		if warning.Code != "" {
+			body += "\n\n" + warning.Code
+		}
+
+		suite := &suites[index]
+		suite.Tests++
+		suite.Failures++
+		suite.TestCases = append(suite.TestCases, junit.TestCase{
+			Name:      location,
+			ClassName: Source(warning),
+			File:      f.path,
+			Line:      warning.Line,
+			Failure: &junit.Failure{
+				Message: f.message,
+				Type:    warning.WarningType,
+				Body:    body,
+			},
+		})
+		failures++
+	}
+
+	tests := failures
+	for _, check := range report.ScanInfo.ChecksPerformed {
+		if _, ok := suiteIndex[check]; ok {
+			continue
+		}
+		suiteIndex[check] = len(suites)
+		suites = append(suites, junit.TestSuite{
+			Name:      check,
+			Tests:     1,
+			TestCases: []junit.TestCase{{Name: check, ClassName: "brakeman." + check}},
+		})
+		tests++
+	}
+
+	return &junit.Report{
+		Name:     "brakeman",
+		Tests:    tests,
+		Failures: failures,
+		Suites:   suites,
+	}
+}
@@ -0,0 +1,56 @@
+package converter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+)
+
+func TestJUnit(t *testing.T) {
+	t.Run("creates a suite per check with failing and passing test cases", func(t *testing.T) {
+		report := &brakeman.Report{
+			ScanInfo: brakeman.ScanInfo{ChecksPerformed: []string{"BasicAuth", "SQL", "Redirect"}},
+			Warnings: []brakeman.Warning{
+				{WarningType: "SQL Injection", CheckName: "SQL", Message: "Possible SQL injection", File: "./app/models/user.rb", Line: 42, Confidence: "High", Code: `User.where("id = #{params[:id]}")`, Fingerprint: "fp1"},
+				{WarningType: "Redirect", CheckName: "Redirect", Message: "Possible unprotected redirect", File: "app/controllers/users_controller.rb", Line: 7, Confidence: "Weak", Fingerprint: "fp2"},
+				{WarningType: "SQL Injection", CheckName: "SQL", Message: "Possible SQL injection", File: "app/models/post.rb", Line: 3, Confidence: "Medium", Fingerprint: "fp3"},
+			},
+		}
+
+		got := (&converter.Converter{}).JUnit(report)
+
+		if got.Tests != 4 || got.Failures != 3 {
+			t.Fatalf("got tests=%d failures=%d, want tests=4 failures=3", got.Tests, got.Failures)
+		}
+		names := make([]string, 0, len(got.Suites))
+		for _, suite := range got.Suites {
+			names = append(names, suite.Name)
+		}
+		if strings.Join(names, ",") != "SQL,Redirect,BasicAuth" {
+			t.Fatalf("got %v, want [SQL Redirect BasicAuth]", names)
+		}
+
+		sql := got.Suites[0]
+		if sql.Tests != 2 || sql.Failures != 2 {
+			t.Fatalf("got tests=%d failures=%d, want tests=2 failures=2", sql.Tests, sql.Failures)
+		}
+		testCase := sql.TestCases[0]
+		if testCase.Name != "app/models/user.rb:42" {
+			t.Fatalf("got %q, want %q", testCase.Name, "app/models/user.rb:42")
+		}
+		if testCase.Failure == nil || testCase.Failure.Message != "Possible SQL injection" {
+			t.Fatalf("got %+v, want a failure with the warning message", testCase.Failure)
+		}
+		want := "app/models/user.rb:42\nPossible SQL injection\n\nUser.where(\"id = #{params[:id]}\")"
+		if testCase.Failure.Body != want {
+			t.Fatalf("got %q, want %q", testCase.Failure.Body, want)
+		}
+
+		passing := got.Suites[2]
+		if passing.Failures != 0 || len(passing.TestCases) != 1 || passing.TestCases[0].Failure != nil {
+			t.Fatalf("got %+v, want a single passing test case", passing)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package converter
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// PathRules rewrites warning paths so that they match the repository layout,
+// e.g. when Brakeman ran inside a container or in a subdirectory.
+// The rules are applied in field order.
+type PathRules struct {
+	// Root makes absolute paths relative to it. Paths outside Root are kept.
+	Root string
+	// StripPrefix is removed from the beginning of paths located under it.
+	StripPrefix string
+	// Rewrites are applied one after another.
+	Rewrites []Rewrite
+	// Prefix is prepended to paths.
+	Prefix string
+}
+
+// Rewrite replaces matches of From with To, which may refer to submatches as in regexp.Regexp.ReplaceAllString.
+type Rewrite struct {
+	From *regexp.Regexp
+	To   string
+}
+
+// ParseRewrite parses a rewrite rule written as "from=to", where from is a regular expression.
+func ParseRewrite(rule string) (Rewrite, error) {
+	from, to, ok := strings.Cut(rule, "=")
+	if !ok {
+		return Rewrite{}, fmt.Errorf("path rewrite %q: expected from=to", rule)
+	}
+
+	re, err := regexp.Compile(from)
+	if err != nil {
+		return Rewrite{}, fmt.Errorf("path rewrite %q: %w", rule, err)
+	}
+	return Rewrite{From: re, To: to}, nil
+}
+
+// Apply rewrites file according to the rules.
+func (p PathRules) Apply(file string) string {
+	file = strings.TrimPrefix(file, "./")
+
+	if p.Root != "" && filepath.IsAbs(file) {
+		if rel, err := filepath.Rel(p.Root, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = filepath.ToSlash(rel)
+		}
+	}
+
+	if p.StripPrefix != "" {
+		if rest, ok := strings.CutPrefix(file, strings.TrimSuffix(p.StripPrefix, "/")+"/"); ok {
+			file = rest
+		}
+	}
+
+	for _, rewrite := range p.Rewrites {
+		file = rewrite.From.ReplaceAllString(file, rewrite.To)
+	}
+
+	if p.Prefix != "" {
+		file = path.Join(p.Prefix, file)
+	}
+
+	return file
+}
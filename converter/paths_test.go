@@ -0,0 +1,144 @@
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+)
+
+func mustParseRewrite(t *testing.T, rule string) converter.Rewrite {
+	t.Helper()
+	rewrite, err := converter.ParseRewrite(rule)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return rewrite
+}
+
+func TestParseRewrite(t *testing.T) {
+	t.Run("parses from=to", func(t *testing.T) {
+		rewrite := mustParseRewrite(t, `^engines/(\w+)/=packs/$1/`)
+		if rewrite.From.String() != `^engines/(\w+)/` {
+			t.Fatalf("got %v, want %v", rewrite.From.String(), `^engines/(\w+)/`)
+		}
+		if rewrite.To != "packs/$1/" {
+			t.Fatalf("got %v, want %v", rewrite.To, "packs/$1/")
+		}
+	})
+
+	t.Run("returns error without separator", func(t *testing.T) {
+		_, err := converter.ParseRewrite("app/")
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+	})
+
+	t.Run("returns error for invalid regular expression", func(t *testing.T) {
+		_, err := converter.ParseRewrite("(app=lib")
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+	})
+}
+
+func TestPathRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules converter.PathRules
+		file  string
+		want  string
+	}{
+		{
+			name:  "removes ./ prefix without rules",
+			rules: converter.PathRules{},
+			file:  "./app/models/user.rb",
+			want:  "app/models/user.rb",
+		},
+		{
+			name:  "makes absolute paths relative to root",
+			rules: converter.PathRules{Root: "/app"},
+			file:  "/app/app/models/user.rb",
+			want:  "app/models/user.rb",
+		},
+		{
+			name:  "keeps absolute paths outside root",
+			rules: converter.PathRules{Root: "/app"},
+			file:  "/usr/lib/ruby/gems/foo.rb",
+			want:  "/usr/lib/ruby/gems/foo.rb",
+		},
+		{
+			name:  "strips prefix",
+			rules: converter.PathRules{StripPrefix: "/builds/group/project"},
+			file:  "/builds/group/project/app/models/user.rb",
+			want:  "app/models/user.rb",
+		},
+		{
+			name:  "strips prefix only at a directory boundary",
+			rules: converter.PathRules{StripPrefix: "src"},
+			file:  "srcs/app/models/user.rb",
+			want:  "srcs/app/models/user.rb",
+		},
+		{
+			name:  "adds prefix",
+			rules: converter.PathRules{Prefix: "services/api"},
+			file:  "app/models/user.rb",
+			want:  "services/api/app/models/user.rb",
+		},
+		{
+			name: "applies rewrites in order",
+			rules: converter.PathRules{
+				Rewrites: []converter.Rewrite{
+					mustParseRewrite(t, `^engines/(\w+)/=packs/$1/`),
+					mustParseRewrite(t, `^packs/billing/=billing/`),
+				},
+			},
+			file: "engines/billing/app/models/invoice.rb",
+			want: "billing/app/models/invoice.rb",
+		},
+		{
+			name: "applies every rule",
+			rules: converter.PathRules{
+				Root:        "/app",
+				StripPrefix: "src",
+				Rewrites:    []converter.Rewrite{mustParseRewrite(t, `\.erb$=.html.erb`)},
+				Prefix:      "services/api",
+			},
+			file: "/app/src/app/views/index.erb",
+			want: "services/api/app/views/index.html.erb",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rules.Apply(tt.file)
+			if got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("are applied to every location by the converter", func(t *testing.T) {
+		c := &converter.Converter{Paths: converter.PathRules{Root: "/app", Prefix: "services/api"}}
+		violations := c.Warnings([]brakeman.Warning{
+			{
+				WarningType: "Cross-Site Scripting",
+				Message:     "Unescaped parameter value",
+				File:        "/app/app/views/users/show.html.erb",
+				Line:        3,
+				Confidence:  "High",
+				RenderPath: []brakeman.RenderPathEntry{
+					{Line: 12, File: "/app/app/controllers/users_controller.rb"},
+				},
+				Fingerprint: "fp1",
+			},
+		})
+
+		if violations[0].Location.Path != "services/api/app/views/users/show.html.erb" {
+			t.Fatalf("got %v, want %v", violations[0].Location.Path, "services/api/app/views/users/show.html.erb")
+		}
+		if violations[0].OtherLocations[0].Path != "services/api/app/controllers/users_controller.rb" {
+			t.Fatalf("got %v, want %v", violations[0].OtherLocations[0].Path, "services/api/app/controllers/users_controller.rb")
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package converter
+
+import (
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/rdjson"
+)
+
+// RDJSONSeverity maps a CodeQuality severity to a reviewdog severity.
+func RDJSONSeverity(severity string) string {
+	switch severity {
+	case "blocker", "critical":
+		return "ERROR"
+	case "major":
+		return "WARNING"
+	default:
+		return "INFO"
+	}
+}
+
+// RDJSON converts a Brakeman report into reviewdog diagnostics whose code is
+// the warning type, linked to the Brakeman documentation.
+func (c *Converter) RDJSON(report *brakeman.Report) *rdjson.DiagnosticResult {
+	diagnostics := make([]rdjson.Diagnostic, 0, len(report.Warnings))
+
+	occurrences := c.occurrences(report.Warnings)
+	for _, warning := range report.Warnings {
+		f, ok := c.finding(warning, occurrences)
+		if !ok {
+			continue
+		}
+
+		diagnostics = append(diagnostics, rdjson.Diagnostic{
+			Message: f.message,
+			Location: rdjson.Location{
+				Path: f.path,
+				Range: &rdjson.Range{
+					Start: rdjson.Position{Line: warning.Line},
+					End:   &rdjson.Position{Line: warning.Line},
+				},
+			},
+			Severity: RDJSONSeverity(f.severity),
+			Code: &rdjson.Code{
+				Value: warning.WarningType,
+				URL:   warning.Link,
+			},
+		})
+	}
+
+	return &rdjson.DiagnosticResult{
+		Source:      &rdjson.Source{Name: "brakeman", URL: "https://brakemanscanner.org"},
+		Diagnostics: diagnostics,
+	}
+}
@@ -0,0 +1,63 @@
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+)
+
+func TestRDJSONSeverity(t *testing.T) {
+	tests := []struct {
+		severity string
+		want     string
+	}{
+		{severity: "blocker", want: "ERROR"},
+		{severity: "critical", want: "ERROR"},
+		{severity: "major", want: "WARNING"},
+		{severity: "minor", want: "INFO"},
+		{severity: "info", want: "INFO"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.severity, func(t *testing.T) {
+			got := converter.RDJSONSeverity(tt.severity)
+			if got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRDJSON(t *testing.T) {
+	t.Run("converts valid warnings into diagnostics", func(t *testing.T) {
+		report := &brakeman.Report{
+			Warnings: []brakeman.Warning{
+				{WarningType: "SQL Injection", Message: "Possible SQL injection", File: "./app/models/user.rb", Line: 42, Confidence: "Medium", Link: "https://brakemanscanner.org/docs/warning_types/sql_injection/", Fingerprint: "fp1"},
+				{WarningType: "Redirect", Message: "Missing line", File: "app/controllers/users_controller.rb", Fingerprint: "fp2"},
+			},
+		}
+
+		got := (&converter.Converter{}).RDJSON(report)
+
+		if got.Source == nil || got.Source.Name != "brakeman" {
+			t.Fatalf("got %+v, want source brakeman", got.Source)
+		}
+		if len(got.Diagnostics) != 1 {
+			t.Fatalf("got %d diagnostics, want 1", len(got.Diagnostics))
+		}
+		diagnostic := got.Diagnostics[0]
+		if diagnostic.Location.Path != "app/models/user.rb" {
+			t.Fatalf("got %q, want %q", diagnostic.Location.Path, "app/models/user.rb")
+		}
+		if diagnostic.Location.Range.Start.Line != 42 || diagnostic.Location.Range.End.Line != 42 {
+			t.Fatalf("got %+v, want line 42", diagnostic.Location.Range)
+		}
+		if diagnostic.Severity != "WARNING" {
+			t.Fatalf("got %q, want %q", diagnostic.Severity, "WARNING")
+		}
+		if diagnostic.Code.Value != "SQL Injection" || diagnostic.Code.URL != "https://brakemanscanner.org/docs/warning_types/sql_injection/" {
+			t.Fatalf("got %+v, want the warning type and link", diagnostic.Code)
+		}
+	})
+}
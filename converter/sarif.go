@@ -0,0 +1,124 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/sarif"
+)
+
+// Level maps a CodeQuality severity to a SARIF result level.
+func Level(severity string) string {
+	switch severity {
+	case "blocker", "critical":
+		return "error"
+	case "major":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// RuleID returns the identifier Brakeman itself uses for a warning code in SARIF output.
+// Warnings without a code get one derived from their warning type instead,
+// such as "sql_injection", so that different kinds never share a rule.
+func RuleID(warning brakeman.Warning) string {
+	if warning.WarningCode == nil {
+		return strings.Join(strings.FieldsFunc(strings.ToLower(warning.WarningType), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}), "_")
+	}
+	return fmt.Sprintf("BRAKE%04d", *warning.WarningCode)
+}
+
+// SARIF converts a Brakeman report into a SARIF log with a single run.
+func (c *Converter) SARIF(report *brakeman.Report) *sarif.Log {
+	rules := []sarif.Rule{}
+	ruleIndex := map[string]int{}
+	results := make([]sarif.Result, 0, len(report.Warnings))
+
+	occurrences := c.occurrences(report.Warnings)
+	for _, warning := range report.Warnings {
+		f, ok := c.finding(warning, occurrences)
+		if !ok {
+			continue
+		}
+
+		id := RuleID(warning)
+		index, ok := ruleIndex[id]
+		if !ok {
+			index = len(rules)
+			ruleIndex[id] = index
+			rules = append(rules, rule(id, warning))
+		}
+
+		result := sarif.Result{
+			RuleID:    id,
+			RuleIndex: index,
+			Level:     Level(f.severity),
+			Message:   sarif.Message{Text: f.message},
+			Locations: []sarif.Location{
+				{
+					PhysicalLocation: sarif.PhysicalLocation{
+						ArtifactLocation: sarif.ArtifactLocation{
+							URI:       f.path,
+							URIBaseID: "%SRCROOT%",
+						},
+						Region: &sarif.Region{StartLine: warning.Line},
+					},
+				},
+			},
+			PartialFingerprints: partialFingerprints(c.Fingerprint, f),
+		}
+
+		results = append(results, result)
+	}
+
+	return &sarif.Log{
+		Schema:  sarif.Schema,
+		Version: sarif.Version,
+		Runs: []sarif.Run{
+			{
+				Tool: sarif.Tool{
+					Driver: sarif.Driver{
+						Name:           "Brakeman",
+						InformationURI: "https://brakemanscanner.org",
+						Version:        report.ScanInfo.BrakemanVersion,
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
+
+// partialFingerprints carries the Brakeman fingerprint when there is one,
+// and the computed fingerprint when another mode is used.
+func partialFingerprints(mode FingerprintMode, f finding) map[string]string {
+	fingerprints := map[string]string{}
+	if f.warning.Fingerprint != "" {
+		fingerprints["brakeman/v1"] = f.warning.Fingerprint
+	}
+	if mode != "" && mode != FingerprintBrakeman {
+		fingerprints[string(mode)+"/v1"] = f.fingerprint
+	}
+	return fingerprints
+}
+
+func rule(id string, warning brakeman.Warning) sarif.Rule {
+	tags := []string{"security"}
+	for _, cwe := range warning.CWEID {
+		tags = append(tags, fmt.Sprintf("external/cwe/cwe-%d", cwe))
+	}
+
+	return sarif.Rule{
+		ID:               id,
+		Name:             warning.CheckName,
+		ShortDescription: &sarif.Message{Text: warning.WarningType},
+		HelpURI:          warning.Link,
+		Properties:       &sarif.RuleProperties{Tags: tags},
+	}
+}
@@ -0,0 +1,160 @@
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+)
+
+func TestLevel(t *testing.T) {
+	tests := []struct {
+		severity string
+		want     string
+	}{
+		{severity: "blocker", want: "error"},
+		{severity: "critical", want: "error"},
+		{severity: "major", want: "warning"},
+		{severity: "minor", want: "note"},
+		{severity: "info", want: "note"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.severity, func(t *testing.T) {
+			got := converter.Level(tt.severity)
+			if got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func warningCode(code int) *int {
+	return &code
+}
+
+func TestSARIF(t *testing.T) {
+	t.Run("converts report into a single run", func(t *testing.T) {
+		report := &brakeman.Report{
+			ScanInfo: brakeman.ScanInfo{BrakemanVersion: "6.1.0"},
+			Warnings: []brakeman.Warning{
+				{
+					WarningType: "SQL Injection",
+					WarningCode: warningCode(0),
+					CheckName:   "SQL",
+					Message:     "Possible SQL injection",
+					File:        "./app/models/user.rb",
+					Line:        42,
+					Link:        "https://brakemanscanner.org/docs/warning_types/sql_injection/",
+					Confidence:  "High",
+					CWEID:       []int{89},
+					Fingerprint: "fp1",
+				},
+				{
+					WarningType: "SQL Injection",
+					WarningCode: warningCode(0),
+					CheckName:   "SQL",
+					Message:     "Possible SQL injection",
+					File:        "app/models/post.rb",
+					Line:        7,
+					Confidence:  "Weak",
+					Fingerprint: "fp2",
+				},
+				{
+					WarningType: "Cross-Site Scripting",
+					WarningCode: warningCode(2),
+					CheckName:   "CrossSiteScripting",
+					Message:     "Unescaped parameter value",
+					File:        "app/views/users/show.html.erb",
+					Line:        3,
+					Confidence:  "Medium",
+					Fingerprint: "fp3",
+				},
+			},
+		}
+
+		log := (&converter.Converter{}).SARIF(report)
+		if log.Version != "2.1.0" {
+			t.Fatalf("got %v, want %v", log.Version, "2.1.0")
+		}
+		if len(log.Runs) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(log.Runs))
+		}
+
+		run := log.Runs[0]
+		if run.Tool.Driver.Version != "6.1.0" {
+			t.Fatalf("got %v, want %v", run.Tool.Driver.Version, "6.1.0")
+		}
+		if len(run.Tool.Driver.Rules) != 2 {
+			t.Fatalf("expected length %d, got %d", 2, len(run.Tool.Driver.Rules))
+		}
+
+		rule := run.Tool.Driver.Rules[0]
+		if rule.ID != "BRAKE0000" {
+			t.Fatalf("got %v, want %v", rule.ID, "BRAKE0000")
+		}
+		if rule.HelpURI != "https://brakemanscanner.org/docs/warning_types/sql_injection/" {
+			t.Fatalf("got %v, want %v", rule.HelpURI, "https://brakemanscanner.org/docs/warning_types/sql_injection/")
+		}
+		if len(rule.Properties.Tags) != 2 || rule.Properties.Tags[1] != "external/cwe/cwe-89" {
+			t.Fatalf("unexpected tags: %v", rule.Properties.Tags)
+		}
+
+		if len(run.Results) != 3 {
+			t.Fatalf("expected length %d, got %d", 3, len(run.Results))
+		}
+		first := run.Results[0]
+		if first.Level != "error" {
+			t.Fatalf("got %v, want %v", first.Level, "error")
+		}
+		if first.Locations[0].PhysicalLocation.ArtifactLocation.URI != "app/models/user.rb" {
+			t.Fatalf("got %v, want %v", first.Locations[0].PhysicalLocation.ArtifactLocation.URI, "app/models/user.rb")
+		}
+		if first.Locations[0].PhysicalLocation.Region.StartLine != 42 {
+			t.Fatalf("got %v, want %v", first.Locations[0].PhysicalLocation.Region.StartLine, 42)
+		}
+		if first.PartialFingerprints["brakeman/v1"] != "fp1" {
+			t.Fatalf("got %v, want %v", first.PartialFingerprints["brakeman/v1"], "fp1")
+		}
+		if run.Results[1].RuleIndex != 0 || run.Results[1].Level != "note" {
+			t.Fatalf("unexpected result: %+v", run.Results[1])
+		}
+		if run.Results[2].RuleID != "BRAKE0002" || run.Results[2].RuleIndex != 1 {
+			t.Fatalf("unexpected result: %+v", run.Results[2])
+		}
+	})
+
+	t.Run("derives rules from the warning type without a warning code", func(t *testing.T) {
+		report := &brakeman.Report{
+			Warnings: []brakeman.Warning{
+				{WarningType: "SQL Injection", Message: "Possible SQL injection", File: "app/models/user.rb", Line: 42, Confidence: "High", Fingerprint: "fp1"},
+				{WarningType: "Cross-Site Scripting", Message: "Unescaped parameter value", File: "app/views/users/show.html.erb", Line: 3, Confidence: "Medium", Fingerprint: "fp2"},
+			},
+		}
+
+		log := (&converter.Converter{}).SARIF(report)
+		rules := log.Runs[0].Tool.Driver.Rules
+		if len(rules) != 2 {
+			t.Fatalf("expected length %d, got %d", 2, len(rules))
+		}
+		if rules[0].ID != "sql_injection" || rules[1].ID != "cross_site_scripting" {
+			t.Fatalf("got %v and %v, want %v and %v", rules[0].ID, rules[1].ID, "sql_injection", "cross_site_scripting")
+		}
+	})
+
+	t.Run("skips invalid warnings", func(t *testing.T) {
+		report := &brakeman.Report{
+			Warnings: []brakeman.Warning{
+				{WarningType: "SQL Injection", Message: "Possible SQL injection", Line: 42, Fingerprint: "fp1"},
+			},
+		}
+
+		log := (&converter.Converter{}).SARIF(report)
+		if len(log.Runs[0].Results) != 0 {
+			t.Fatalf("expected length %d, got %d", 0, len(log.Runs[0].Results))
+		}
+		if len(log.Runs[0].Tool.Driver.Rules) != 0 {
+			t.Fatalf("expected length %d, got %d", 0, len(log.Runs[0].Tool.Driver.Rules))
+		}
+	})
+}
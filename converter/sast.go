@@ -0,0 +1,125 @@
+package converter
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/sast"
+)
+
+// SASTSeverity maps a CodeQuality severity to a GitLab SAST severity.
+func SASTSeverity(severity string) string {
+	switch severity {
+	case "blocker":
+		return "Critical"
+	case "critical":
+		return "High"
+	case "major":
+		return "Medium"
+	case "minor":
+		return "Low"
+	case "info":
+		return "Info"
+	default:
+		return "Unknown"
+	}
+}
+
+// SAST converts a Brakeman report into a GitLab SAST report.
+// analyzerVersion is the version of this tool, reported as the analyzer.
+func (c *Converter) SAST(report *brakeman.Report, analyzerVersion string) *sast.Report {
+	vulnerabilities := make([]sast.Vulnerability, 0, len(report.Warnings))
+
+	occurrences := c.occurrences(report.Warnings)
+	for _, warning := range report.Warnings {
+		f, ok := c.finding(warning, occurrences)
+		if !ok {
+			continue
+		}
+
+		location := sast.Location{
+			File:      f.path,
+			StartLine: warning.Line,
+		}
+		if warning.Location != nil {
+			location.Class = warning.Location.Class
+			location.Method = warning.Location.Method
+		}
+
+		vulnerability := sast.Vulnerability{
+			ID:          f.fingerprint,
+			Name:        warning.WarningType,
+			Description: f.message,
+			Severity:    SASTSeverity(f.severity),
+			Identifiers: identifiers(warning),
+			Location:    location,
+		}
+
+		vulnerabilities = append(vulnerabilities, vulnerability)
+	}
+
+	return &sast.Report{
+		Version: sast.SchemaVersion,
+		Scan: sast.Scan{
+			Analyzer: sast.Tool{
+				ID:      "brakeman-to-codequality",
+				Name:    "brakeman-to-codequality",
+				URL:     "https://github.com/Omochice/brakeman-to-codequality",
+				Version: analyzerVersion,
+				Vendor:  sast.Vendor{Name: "Omochice"},
+			},
+			Scanner: sast.Tool{
+				ID:      "brakeman",
+				Name:    "Brakeman",
+				URL:     "https://brakemanscanner.org",
+				Version: report.ScanInfo.BrakemanVersion,
+				Vendor:  sast.Vendor{Name: "Brakeman"},
+			},
+			Type:      "sast",
+			StartTime: scanTime(report.ScanInfo.StartedAt()),
+			EndTime:   scanTime(report.ScanInfo.EndedAt()),
+			Status:    "success",
+		},
+		Vulnerabilities: vulnerabilities,
+	}
+}
+
+// identifiers lists the Brakeman warning code first so GitLab uses it as the primary identifier.
+// Warnings without a code are identified by their warning type.
+func identifiers(warning brakeman.Warning) []sast.Identifier {
+	primary := sast.Identifier{
+		Type:  "brakeman_warning_type",
+		Name:  "Brakeman Warning Type " + warning.WarningType,
+		Value: RuleID(warning),
+		URL:   warning.Link,
+	}
+	if warning.WarningCode != nil {
+		code := strconv.Itoa(*warning.WarningCode)
+		primary.Type = "brakeman_warning_code"
+		primary.Name = "Brakeman Warning Code " + code
+		primary.Value = code
+	}
+	ids := []sast.Identifier{primary}
+
+	for _, cwe := range warning.CWEID {
+		ids = append(ids, sast.Identifier{
+			Type:  "cwe",
+			Name:  fmt.Sprintf("CWE-%d", cwe),
+			Value: strconv.Itoa(cwe),
+			URL:   fmt.Sprintf("https://cwe.mitre.org/data/definitions/%d.html", cwe),
+		})
+	}
+
+	return ids
+}
+
+// scanTime formats a Brakeman timestamp for the SAST report.
+// The schema requires both timestamps, so the current time is used when Brakeman did not record one.
+func scanTime(t time.Time, err error) string {
+	if err != nil {
+		t = time.Now()
+	}
+	return t.UTC().Format(sast.TimeLayout)
+}
@@ -0,0 +1,120 @@
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+)
+
+func TestSASTSeverity(t *testing.T) {
+	tests := []struct {
+		severity string
+		want     string
+	}{
+		{severity: "blocker", want: "Critical"},
+		{severity: "critical", want: "High"},
+		{severity: "major", want: "Medium"},
+		{severity: "minor", want: "Low"},
+		{severity: "info", want: "Info"},
+		{severity: "", want: "Unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.severity, func(t *testing.T) {
+			got := converter.SASTSeverity(tt.severity)
+			if got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSAST(t *testing.T) {
+	t.Run("converts report into vulnerabilities", func(t *testing.T) {
+		report := &brakeman.Report{
+			ScanInfo: brakeman.ScanInfo{
+				BrakemanVersion: "6.1.0",
+				StartTime:       "2024-01-15 19:00:00 +0900",
+				EndTime:         "2024-01-15 19:00:05 +0900",
+			},
+			Warnings: []brakeman.Warning{
+				{
+					WarningType: "SQL Injection",
+					WarningCode: warningCode(0),
+					Message:     "Possible SQL injection",
+					File:        "./app/models/user.rb",
+					Line:        42,
+					Link:        "https://brakemanscanner.org/docs/warning_types/sql_injection/",
+					Confidence:  "High",
+					Location:    &brakeman.Location{Type: "method", Class: "User", Method: "search"},
+					CWEID:       []int{89},
+					Fingerprint: "fp1",
+				},
+				{
+					WarningType: "SQL Injection",
+					Message:     "Possible SQL injection",
+					Line:        42,
+					Fingerprint: "fp2",
+				},
+			},
+		}
+
+		result := (&converter.Converter{}).SAST(report, "1.2.3")
+		if result.Scan.Scanner.Version != "6.1.0" {
+			t.Fatalf("got %v, want %v", result.Scan.Scanner.Version, "6.1.0")
+		}
+		if result.Scan.Analyzer.Version != "1.2.3" {
+			t.Fatalf("got %v, want %v", result.Scan.Analyzer.Version, "1.2.3")
+		}
+		if result.Scan.StartTime != "2024-01-15T10:00:00" {
+			t.Fatalf("got %v, want %v", result.Scan.StartTime, "2024-01-15T10:00:00")
+		}
+		if result.Scan.EndTime != "2024-01-15T10:00:05" {
+			t.Fatalf("got %v, want %v", result.Scan.EndTime, "2024-01-15T10:00:05")
+		}
+		if len(result.Vulnerabilities) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(result.Vulnerabilities))
+		}
+
+		vulnerability := result.Vulnerabilities[0]
+		if vulnerability.Severity != "High" {
+			t.Fatalf("got %v, want %v", vulnerability.Severity, "High")
+		}
+		if vulnerability.Location.File != "app/models/user.rb" || vulnerability.Location.StartLine != 42 {
+			t.Fatalf("unexpected location: %+v", vulnerability.Location)
+		}
+		if vulnerability.Location.Class != "User" || vulnerability.Location.Method != "search" {
+			t.Fatalf("unexpected location: %+v", vulnerability.Location)
+		}
+		if len(vulnerability.Identifiers) != 2 {
+			t.Fatalf("expected length %d, got %d", 2, len(vulnerability.Identifiers))
+		}
+		if vulnerability.Identifiers[0].Type != "brakeman_warning_code" || vulnerability.Identifiers[0].Value != "0" {
+			t.Fatalf("unexpected identifier: %+v", vulnerability.Identifiers[0])
+		}
+		if vulnerability.Identifiers[1].Name != "CWE-89" {
+			t.Fatalf("got %v, want %v", vulnerability.Identifiers[1].Name, "CWE-89")
+		}
+	})
+
+	t.Run("identifies warnings without a code by their warning type", func(t *testing.T) {
+		report := &brakeman.Report{
+			Warnings: []brakeman.Warning{
+				{WarningType: "SQL Injection", Message: "Possible SQL injection", File: "app/models/user.rb", Line: 42, Confidence: "High", Fingerprint: "fp1"},
+			},
+		}
+
+		identifier := (&converter.Converter{}).SAST(report, "1.2.3").Vulnerabilities[0].Identifiers[0]
+		if identifier.Type != "brakeman_warning_type" || identifier.Value != "sql_injection" {
+			t.Fatalf("unexpected identifier: %+v", identifier)
+		}
+	})
+
+	t.Run("fills scan times when Brakeman did not record them", func(t *testing.T) {
+		result := (&converter.Converter{}).SAST(&brakeman.Report{}, "1.2.3")
+		if result.Scan.StartTime == "" || result.Scan.EndTime == "" {
+			t.Fatalf("expected scan times to be set, got %+v", result.Scan)
+		}
+	})
+}
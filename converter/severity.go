@@ -0,0 +1,127 @@
+package converter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+)
+
+// severities lists the CodeQuality severities from least to most severe.
+var severities = []string{"info", "minor", "major", "critical", "blocker"}
+
+// IsSeverity reports whether severity is one of the CodeQuality severities.
+func IsSeverity(severity string) bool {
+	return slices.Contains(severities, severity)
+}
+
+// AtLeast reports whether severity is equal to or more severe than threshold.
+// Unknown severities never meet a threshold, and nothing meets an unknown one.
+func AtLeast(severity string, threshold string) bool {
+	rank := slices.Index(severities, severity)
+	minimum := slices.Index(severities, threshold)
+	return rank >= 0 && minimum >= 0 && rank >= minimum
+}
+
+// SeverityConfig customizes how warnings are mapped to CodeQuality severities.
+//
+// A warning is matched against Rules first. The rule with the most criteria
+// that all match wins, and among equally specific rules the first one wins.
+// When no rule matches, Confidence is consulted, and finally Severity.
+type SeverityConfig struct {
+	// Confidence maps a Brakeman confidence level (case-insensitive) to a severity.
+	Confidence map[string]string `yaml:"confidence"`
+	Rules      []SeverityRule    `yaml:"rules"`
+}
+
+// SeverityRule assigns Severity to warnings matching every criterion that is set.
+type SeverityRule struct {
+	WarningType string `yaml:"warning_type"`
+	WarningCode *int   `yaml:"warning_code"`
+	Confidence  string `yaml:"confidence"`
+	Severity    string `yaml:"severity"`
+}
+
+// ParseSeverityConfig decodes a severity configuration in YAML or JSON from r.
+func ParseSeverityConfig(r io.Reader) (*SeverityConfig, error) {
+	var config SeverityConfig
+
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	confidence := make(map[string]string, len(config.Confidence))
+	for level, severity := range config.Confidence {
+		if !slices.Contains(severities, severity) {
+			return nil, fmt.Errorf("confidence %q: unknown severity %q", level, severity)
+		}
+		confidence[strings.ToLower(level)] = severity
+	}
+	config.Confidence = confidence
+
+	for i, rule := range config.Rules {
+		if !slices.Contains(severities, rule.Severity) {
+			return nil, fmt.Errorf("rules[%d]: unknown severity %q", i, rule.Severity)
+		}
+		if rule.specificity() == 0 {
+			return nil, fmt.Errorf("rules[%d]: at least one of warning_type, warning_code or confidence is required", i)
+		}
+	}
+
+	return &config, nil
+}
+
+// Severity returns the configured severity for warning.
+func (s *SeverityConfig) Severity(warning brakeman.Warning) string {
+	best := -1
+	specificity := 0
+	for i, rule := range s.Rules {
+		if rule.matches(warning) && rule.specificity() > specificity {
+			best = i
+			specificity = rule.specificity()
+		}
+	}
+	if best >= 0 {
+		return s.Rules[best].Severity
+	}
+
+	if severity, ok := s.Confidence[strings.ToLower(string(warning.Confidence))]; ok {
+		return severity
+	}
+
+	return Severity(string(warning.Confidence))
+}
+
+func (r SeverityRule) matches(warning brakeman.Warning) bool {
+	if r.WarningType != "" && !strings.EqualFold(r.WarningType, warning.WarningType) {
+		return false
+	}
+	if r.WarningCode != nil && (warning.WarningCode == nil || *r.WarningCode != *warning.WarningCode) {
+		return false
+	}
+	if r.Confidence != "" && !strings.EqualFold(r.Confidence, string(warning.Confidence)) {
+		return false
+	}
+	return true
+}
+
+func (r SeverityRule) specificity() int {
+	n := 0
+	if r.WarningType != "" {
+		n++
+	}
+	if r.WarningCode != nil {
+		n++
+	}
+	if r.Confidence != "" {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,174 @@
+package converter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+)
+
+func TestAtLeast(t *testing.T) {
+	tests := []struct {
+		severity  string
+		threshold string
+		want      bool
+	}{
+		{severity: "critical", threshold: "major", want: true},
+		{severity: "major", threshold: "major", want: true},
+		{severity: "minor", threshold: "major", want: false},
+		{severity: "blocker", threshold: "info", want: true},
+		{severity: "unknown", threshold: "info", want: false},
+		{severity: "info", threshold: "Critical", want: false},
+		{severity: "blocker", threshold: "high", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.severity+" against "+tt.threshold, func(t *testing.T) {
+			got := converter.AtLeast(tt.severity, tt.threshold)
+			if got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSeverityConfig(t *testing.T) {
+	t.Run("parses YAML", func(t *testing.T) {
+		input := `
+confidence:
+  Weak: info
+rules:
+  - warning_type: SQL Injection
+    severity: blocker
+`
+		config, err := converter.ParseSeverityConfig(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if config.Confidence["weak"] != "info" {
+			t.Fatalf("got %v, want %v", config.Confidence["weak"], "info")
+		}
+		if len(config.Rules) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(config.Rules))
+		}
+	})
+
+	t.Run("parses JSON", func(t *testing.T) {
+		input := `{"rules":[{"warning_code":0,"severity":"blocker"}]}`
+		config, err := converter.ParseSeverityConfig(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if config.Rules[0].WarningCode == nil || *config.Rules[0].WarningCode != 0 {
+			t.Fatalf("got %v, want %v", config.Rules[0].WarningCode, 0)
+		}
+	})
+
+	t.Run("accepts empty input", func(t *testing.T) {
+		_, err := converter.ParseSeverityConfig(strings.NewReader(""))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("returns error for unknown severity", func(t *testing.T) {
+		_, err := converter.ParseSeverityConfig(strings.NewReader("confidence:\n  high: severe\n"))
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+	})
+
+	t.Run("returns error for rule without criteria", func(t *testing.T) {
+		_, err := converter.ParseSeverityConfig(strings.NewReader("rules:\n  - severity: blocker\n"))
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+	})
+
+	t.Run("returns error for unknown key", func(t *testing.T) {
+		_, err := converter.ParseSeverityConfig(strings.NewReader("rules:\n  - check: SQL\n    severity: blocker\n"))
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+	})
+}
+
+func TestSeverityConfig(t *testing.T) {
+	input := `
+confidence:
+  weak: info
+rules:
+  - warning_type: SQL Injection
+    severity: blocker
+  - warning_type: SQL Injection
+    confidence: weak
+    severity: major
+  - warning_code: 2
+    severity: critical
+  - warning_code: 2
+    severity: minor
+`
+	config, err := converter.ParseSeverityConfig(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		warning brakeman.Warning
+		want    string
+	}{
+		{
+			name:    "matches by warning type at any confidence",
+			warning: brakeman.Warning{WarningType: "SQL Injection", Confidence: "Medium"},
+			want:    "blocker",
+		},
+		{
+			name:    "prefers the more specific combination",
+			warning: brakeman.Warning{WarningType: "SQL Injection", Confidence: "Weak"},
+			want:    "major",
+		},
+		{
+			name:    "prefers the first of equally specific rules",
+			warning: brakeman.Warning{WarningType: "Cross-Site Scripting", WarningCode: warningCode(2), Confidence: "High"},
+			want:    "critical",
+		},
+		{
+			name:    "falls back to confidence mapping",
+			warning: brakeman.Warning{WarningType: "Redirect", WarningCode: warningCode(18), Confidence: "Weak"},
+			want:    "info",
+		},
+		{
+			name:    "falls back to default mapping",
+			warning: brakeman.Warning{WarningType: "Redirect", WarningCode: warningCode(18), Confidence: "High"},
+			want:    "critical",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := config.Severity(tt.warning)
+			if got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("is applied by the converter", func(t *testing.T) {
+		c := &converter.Converter{Severities: config}
+		violations := c.Warnings([]brakeman.Warning{
+			{
+				WarningType: "SQL Injection",
+				Message:     "Possible SQL injection",
+				File:        "app/models/user.rb",
+				Line:        42,
+				Confidence:  "Medium",
+				Fingerprint: "fp1",
+			},
+		})
+		if violations[0].Severity != "blocker" {
+			t.Fatalf("got %v, want %v", violations[0].Severity, "blocker")
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+)
+
+// Skip describes a warning that cannot be converted because it lacks required fields.
+type Skip struct {
+	// Index is the position of the warning in the converted slice.
+	Index   int
+	Warning brakeman.Warning
+	// Missing lists the JSON names of the missing fields.
+	Missing []string
+}
+
+func (s Skip) String() string {
+	name := s.Warning.WarningType
+	if name == "" {
+		name = "unknown warning"
+	}
+	return fmt.Sprintf("warning #%d (%s at %s:%d): missing %s", s.Index+1, name, s.Warning.File, s.Warning.Line, strings.Join(s.Missing, ", "))
+}
+
+// Skipped reports the warnings that the conversion methods of c leave out
+// because they lack required fields. Warnings dropped on purpose, such as
+// ignored or baseline warnings, are not reported.
+func (c *Converter) Skipped(warnings []brakeman.Warning) []Skip {
+	var skips []Skip
+	for i, warning := range warnings {
+		if skip, ok := c.Validate(i, warning); !ok {
+			skips = append(skips, skip)
+		}
+	}
+	return skips
+}
+
+// Validate checks a single warning found at index. It reports false, along
+// with the reason, when the warning lacks required fields.
+func (c *Converter) Validate(index int, warning brakeman.Warning) (Skip, bool) {
+	if missing := c.missing(warning); len(missing) > 0 {
+		return Skip{Index: index, Warning: warning, Missing: missing}, false
+	}
+	return Skip{}, true
+}
@@ -0,0 +1,68 @@
+package converter_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+)
+
+func TestSkipped(t *testing.T) {
+	warnings := []brakeman.Warning{
+		{
+			WarningType: "SQL Injection",
+			Message:     "Possible SQL injection",
+			File:        "app/models/user.rb",
+			Line:        42,
+			Fingerprint: "fp1",
+		},
+		{
+			WarningType: "Redirect",
+			File:        "app/controllers/users_controller.rb",
+		},
+		{
+			WarningType: "Cross-Site Scripting",
+			Message:     "Unescaped parameter value",
+			File:        "app/views/users/show.html.erb",
+			Line:        3,
+		},
+	}
+
+	t.Run("reports each skipped warning with the missing fields", func(t *testing.T) {
+		skips := (&converter.Converter{}).Skipped(warnings)
+		if len(skips) != 2 {
+			t.Fatalf("expected length %d, got %d", 2, len(skips))
+		}
+		if skips[0].Index != 1 {
+			t.Fatalf("got %v, want %v", skips[0].Index, 1)
+		}
+		if !slices.Equal(skips[0].Missing, []string{"line", "message", "fingerprint"}) {
+			t.Fatalf("got %v, want %v", skips[0].Missing, []string{"line", "message", "fingerprint"})
+		}
+		want := "warning #2 (Redirect at app/controllers/users_controller.rb:0): missing line, message, fingerprint"
+		if skips[0].String() != want {
+			t.Fatalf("got %q, want %q", skips[0].String(), want)
+		}
+		if !slices.Equal(skips[1].Missing, []string{"fingerprint"}) {
+			t.Fatalf("got %v, want %v", skips[1].Missing, []string{"fingerprint"})
+		}
+	})
+
+	t.Run("does not require a fingerprint when it is computed", func(t *testing.T) {
+		skips := (&converter.Converter{Fingerprint: converter.FingerprintComputed}).Skipped(warnings)
+		if len(skips) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(skips))
+		}
+	})
+
+	t.Run("does not report ignored warnings", func(t *testing.T) {
+		c := &converter.Converter{
+			Ignore: &brakeman.Ignore{IgnoredWarnings: []brakeman.IgnoredWarning{{Warning: brakeman.Warning{Fingerprint: "fp1"}}}},
+		}
+		skips := c.Skipped(warnings[:1])
+		if len(skips) != 0 {
+			t.Fatalf("expected length %d, got %d", 0, len(skips))
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/sonar"
+)
+
+// SonarSeverity maps a CodeQuality severity to a SonarQube issue severity.
+func SonarSeverity(severity string) string {
+	switch severity {
+	case "blocker", "critical", "major", "minor":
+		return strings.ToUpper(severity)
+	default:
+		return "INFO"
+	}
+}
+
+// SonarImpact maps a CodeQuality severity to the severity of a SonarQube
+// software quality impact.
+func SonarImpact(severity string) string {
+	switch severity {
+	case "blocker", "critical":
+		return "HIGH"
+	case "major":
+		return "MEDIUM"
+	default:
+		return "LOW"
+	}
+}
+
+// Sonar converts a Brakeman report into SonarQube generic issues, reported as
+// vulnerabilities under one rule per RuleID, so that warnings without a code
+// are still kept apart by warning type.
+// A rule takes the severity of its first warning.
+func (c *Converter) Sonar(report *brakeman.Report) *sonar.Report {
+	rules := []sonar.Rule{}
+	ruleIDs := map[string]bool{}
+	issues := make([]sonar.Issue, 0, len(report.Warnings))
+
+	occurrences := c.occurrences(report.Warnings)
+	for _, warning := range report.Warnings {
+		f, ok := c.finding(warning, occurrences)
+		if !ok {
+			continue
+		}
+
+		id := RuleID(warning)
+		if !ruleIDs[id] {
+			ruleIDs[id] = true
+			rules = append(rules, sonarRule(id, warning, f.severity))
+		}
+
+		issues = append(issues, sonar.Issue{
+			EngineID: "brakeman",
+			RuleID:   id,
+			Severity: SonarSeverity(f.severity),
+			Type:     "VULNERABILITY",
+			PrimaryLocation: sonar.Location{
+				Message:   f.message,
+				FilePath:  f.path,
+				TextRange: &sonar.TextRange{StartLine: warning.Line},
+			},
+		})
+	}
+
+	return &sonar.Report{
+		Rules:  rules,
+		Issues: issues,
+	}
+}
+
+func sonarRule(id string, warning brakeman.Warning, severity string) sonar.Rule {
+	description := warning.WarningType
+	if warning.Link != "" {
+		description += ": " + warning.Link
+	}
+
+	return sonar.Rule{
+		ID:                 id,
+		Name:               warning.WarningType,
+		Description:        description,
+		EngineID:           "brakeman",
+		CleanCodeAttribute: "TRUSTWORTHY",
+		Type:               "VULNERABILITY",
+		Severity:           SonarSeverity(severity),
+		Impacts: []sonar.Impact{
+			{SoftwareQuality: "SECURITY", Severity: SonarImpact(severity)},
+		},
+	}
+}
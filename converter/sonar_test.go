@@ -0,0 +1,92 @@
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+)
+
+func TestSonarSeverity(t *testing.T) {
+	tests := []struct {
+		severity string
+		want     string
+		impact   string
+	}{
+		{severity: "blocker", want: "BLOCKER", impact: "HIGH"},
+		{severity: "critical", want: "CRITICAL", impact: "HIGH"},
+		{severity: "major", want: "MAJOR", impact: "MEDIUM"},
+		{severity: "minor", want: "MINOR", impact: "LOW"},
+		{severity: "info", want: "INFO", impact: "LOW"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.severity, func(t *testing.T) {
+			if got := converter.SonarSeverity(tt.severity); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			if got := converter.SonarImpact(tt.severity); got != tt.impact {
+				t.Fatalf("got %v, want %v", got, tt.impact)
+			}
+		})
+	}
+}
+
+func TestSonar(t *testing.T) {
+	t.Run("converts warnings into vulnerabilities with one rule per warning code", func(t *testing.T) {
+		report := &brakeman.Report{
+			Warnings: []brakeman.Warning{
+				{WarningType: "SQL Injection", WarningCode: warningCode(0), Message: "Possible SQL injection", File: "./app/models/user.rb", Line: 42, Confidence: "High", Link: "https://brakemanscanner.org/docs/warning_types/sql_injection/", Fingerprint: "fp1"},
+				{WarningType: "SQL Injection", WarningCode: warningCode(0), Message: "Possible SQL injection", File: "app/models/post.rb", Line: 3, Confidence: "Weak", Fingerprint: "fp2"},
+				{WarningType: "Redirect", WarningCode: warningCode(18), Message: "Possible unprotected redirect", File: "app/controllers/users_controller.rb", Line: 7, Confidence: "Medium", Fingerprint: "fp3"},
+			},
+		}
+
+		got := (&converter.Converter{}).Sonar(report)
+
+		if len(got.Rules) != 2 {
+			t.Fatalf("got %d rules, want 2", len(got.Rules))
+		}
+		rule := got.Rules[0]
+		if rule.ID != "BRAKE0000" || rule.Name != "SQL Injection" || rule.EngineID != "brakeman" {
+			t.Fatalf("got %+v", rule)
+		}
+		if rule.CleanCodeAttribute != "TRUSTWORTHY" || len(rule.Impacts) != 1 || rule.Impacts[0].SoftwareQuality != "SECURITY" || rule.Impacts[0].Severity != "HIGH" {
+			t.Fatalf("got %+v, want a trustworthy rule with a high security impact", rule)
+		}
+
+		if len(got.Issues) != 3 {
+			t.Fatalf("got %d issues, want 3", len(got.Issues))
+		}
+		issue := got.Issues[1]
+		if issue.RuleID != "BRAKE0000" || issue.Severity != "MINOR" || issue.Type != "VULNERABILITY" || issue.EngineID != "brakeman" {
+			t.Fatalf("got %+v", issue)
+		}
+		if issue.PrimaryLocation.FilePath != "app/models/post.rb" || issue.PrimaryLocation.TextRange.StartLine != 3 {
+			t.Fatalf("got %+v", issue.PrimaryLocation)
+		}
+		if got.Issues[2].RuleID != "BRAKE0018" {
+			t.Fatalf("got %q, want %q", got.Issues[2].RuleID, "BRAKE0018")
+		}
+	})
+
+	t.Run("keeps warning types apart without warning codes", func(t *testing.T) {
+		report := &brakeman.Report{
+			Warnings: []brakeman.Warning{
+				{WarningType: "SQL Injection", Message: "Possible SQL injection", File: "app/models/user.rb", Line: 42, Confidence: "High", Fingerprint: "fp1"},
+				{WarningType: "Redirect", Message: "Possible unprotected redirect", File: "app/controllers/users_controller.rb", Line: 7, Confidence: "Weak", Fingerprint: "fp2"},
+			},
+		}
+
+		got := (&converter.Converter{}).Sonar(report)
+		if len(got.Rules) != 2 {
+			t.Fatalf("got %d rules, want 2", len(got.Rules))
+		}
+		if got.Rules[1].ID != "redirect" || got.Rules[1].Name != "Redirect" {
+			t.Fatalf("got %+v", got.Rules[1])
+		}
+		if got.Issues[0].RuleID != "sql_injection" || got.Issues[1].RuleID != "redirect" {
+			t.Fatalf("got %q and %q, want %q and %q", got.Issues[0].RuleID, got.Issues[1].RuleID, "sql_injection", "redirect")
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package converter
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/text"
+)
+
+// Text converts a Brakeman report into findings for the terminal, grouped by
+// file and sorted by path and line.
+func (c *Converter) Text(report *brakeman.Report) *text.Report {
+	files := []text.File{}
+	fileIndex := map[string]int{}
+
+	occurrences := c.occurrences(report.Warnings)
+	for _, warning := range report.Warnings {
+		f, ok := c.finding(warning, occurrences)
+		if !ok {
+			continue
+		}
+
+		index, ok := fileIndex[f.path]
+		if !ok {
+			index = len(files)
+			fileIndex[f.path] = index
+			files = append(files, text.File{Path: f.path})
+		}
+
+		files[index].Findings = append(files[index].Findings, text.Finding{
+			Line:     warning.Line,
+			Severity: f.severity,
+			Type:     warning.WarningType,
+			Message:  f.message,
+			Code:     warning.Code,
+		})
+	}
+
+	slices.SortFunc(files, func(a, b text.File) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+	for _, file := range files {
+		slices.SortStableFunc(file.Findings, func(a, b text.Finding) int {
+			return cmp.Compare(a.Line, b.Line)
+		})
+	}
+
+	return &text.Report{Files: files}
+}
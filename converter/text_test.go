@@ -0,0 +1,37 @@
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+)
+
+func TestText(t *testing.T) {
+	t.Run("groups findings by file sorted by path and line", func(t *testing.T) {
+		report := &brakeman.Report{
+			Warnings: []brakeman.Warning{
+				{WarningType: "SQL Injection", Message: "Possible SQL injection", File: "./app/models/user.rb", Line: 42, Confidence: "High", Code: "User.where(...)", Fingerprint: "fp1"},
+				{WarningType: "Redirect", Message: "Possible unprotected redirect", File: "app/controllers/users_controller.rb", Line: 7, Confidence: "Medium", Fingerprint: "fp2"},
+				{WarningType: "Mass Assignment", Message: "Potentially dangerous attribute", File: "app/models/user.rb", Line: 3, Confidence: "Weak", Fingerprint: "fp3"},
+				{WarningType: "Redirect", Message: "Missing line", File: "app/controllers/posts_controller.rb", Fingerprint: "fp4"},
+			},
+		}
+
+		got := (&converter.Converter{}).Text(report)
+
+		if len(got.Files) != 2 {
+			t.Fatalf("got %d files, want 2", len(got.Files))
+		}
+		if got.Files[0].Path != "app/controllers/users_controller.rb" || got.Files[1].Path != "app/models/user.rb" {
+			t.Fatalf("got %q and %q, want files sorted by path", got.Files[0].Path, got.Files[1].Path)
+		}
+		findings := got.Files[1].Findings
+		if len(findings) != 2 || findings[0].Line != 3 || findings[1].Line != 42 {
+			t.Fatalf("got %+v, want findings sorted by line", findings)
+		}
+		if findings[1].Severity != "critical" || findings[1].Code != "User.where(...)" {
+			t.Fatalf("got %+v", findings[1])
+		}
+	})
+}
@@ -163,7 +163,7 @@
             meta.license = pkgs.lib.licenses.zlib;
             pname = "brakeman-to-codequality";
             src = ./.;
-            vendorHash = "sha256-x0Xzh7SYDE4mSwTl2XFHeZ+CqB6hzzeJcwNXYMEo6q0=";
+            vendorHash = "sha256-h45LQbUtrm67+OcsFb5A6n0CrZswTjEMZ68oTerVRg4=";
             version = version;
             #keep-sorted end
           };
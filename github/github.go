@@ -0,0 +1,43 @@
+package github
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Annotation is a GitHub Actions workflow command that annotates a line of a file.
+type Annotation struct {
+	// Command is error, warning or notice.
+	Command string
+	File    string
+	Line    int
+	Title   string
+	Message string
+}
+
+var (
+	dataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	propertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+func (a Annotation) String() string {
+	properties := []string{"file=" + propertyEscaper.Replace(a.File)}
+	if a.Line > 0 {
+		properties = append(properties, fmt.Sprintf("line=%d", a.Line))
+	}
+	if a.Title != "" {
+		properties = append(properties, "title="+propertyEscaper.Replace(a.Title))
+	}
+	return fmt.Sprintf("::%s %s::%s", a.Command, strings.Join(properties, ","), dataEscaper.Replace(a.Message))
+}
+
+// Write prints annotations into w, one workflow command per line.
+func Write(annotations []Annotation, w io.Writer) error {
+	for _, annotation := range annotations {
+		if _, err := fmt.Fprintln(w, annotation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package github_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/github"
+)
+
+func TestAnnotation(t *testing.T) {
+	t.Run("formats a workflow command", func(t *testing.T) {
+		annotation := github.Annotation{Command: "error", File: "app/models/user.rb", Line: 42, Title: "SQL Injection", Message: "Possible SQL injection"}
+
+		want := "::error file=app/models/user.rb,line=42,title=SQL Injection::Possible SQL injection"
+		if got := annotation.String(); got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("escapes data and properties", func(t *testing.T) {
+		annotation := github.Annotation{Command: "warning", File: "app/a,b:c.rb", Title: "100%, really: yes", Message: "50% of\r\nlines: a, b"}
+
+		want := "::warning file=app/a%2Cb%3Ac.rb,title=100%25%2C really%3A yes::50%25 of%0D%0Alines: a, b"
+		if got := annotation.String(); got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("writes one command per line", func(t *testing.T) {
+		annotations := []github.Annotation{
+			{Command: "error", File: "a.rb", Line: 1, Message: "first"},
+			{Command: "notice", File: "b.rb", Line: 2, Message: "second"},
+		}
+
+		var buf bytes.Buffer
+		if err := github.Write(annotations, &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := "::error file=a.rb,line=1::first\n::notice file=b.rb,line=2::second\n"
+		if buf.String() != want {
+			t.Fatalf("got %q, want %q", buf.String(), want)
+		}
+	})
+
+	t.Run("writes nothing without annotations", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := github.Write(nil, &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if buf.Len() != 0 {
+			t.Fatalf("expected empty output, got %q", buf.String())
+		}
+	})
+}
@@ -2,6 +2,9 @@ module github.com/Omochice/brakeman-to-codequality
 
 go 1.25.5
 
-require github.com/jessevdk/go-flags v1.6.1
+require (
+	github.com/jessevdk/go-flags v1.6.1
+	go.yaml.in/yaml/v3 v3.0.4
+)
 
 require golang.org/x/sys v0.21.0 // indirect
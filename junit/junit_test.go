@@ -0,0 +1,61 @@
+package junit_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/junit"
+)
+
+func TestWrite(t *testing.T) {
+	t.Run("writes JUnit XML", func(t *testing.T) {
+		report := &junit.Report{
+			Name:     "brakeman",
+			Tests:    2,
+			Failures: 1,
+			Suites: []junit.TestSuite{
+				{
+					Name:     "SQL",
+					Tests:    1,
+					Failures: 1,
+					TestCases: []junit.TestCase{
+						{
+							Name:      "app/models/user.rb:42",
+							ClassName: "brakeman.SQL",
+							Failure:   &junit.Failure{Message: "Possible SQL injection", Type: "SQL Injection", Body: `User.where("id = #{params[:id]}")`},
+						},
+					},
+				},
+				{
+					Name:      "Redirect",
+					Tests:     1,
+					TestCases: []junit.TestCase{{Name: "Redirect", ClassName: "brakeman.Redirect"}},
+				},
+			},
+		}
+
+		var buf bytes.Buffer
+		if err := junit.Write(report, &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		output := buf.String()
+		if !strings.HasPrefix(output, "<?xml") {
+			t.Fatalf("expected %q to start with the XML declaration", output)
+		}
+		if strings.Count(output, "<failure") != 1 {
+			t.Fatalf("expected a single failure element, got %q", output)
+		}
+
+		var decoded junit.Report
+		if err := xml.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("failed to decode output as XML: %v", err)
+		}
+		body := decoded.Suites[0].TestCases[0].Failure.Body
+		if body != report.Suites[0].TestCases[0].Failure.Body {
+			t.Fatalf("got %q, want %q", body, report.Suites[0].TestCases[0].Failure.Body)
+		}
+	})
+}
@@ -1,26 +1,76 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 
 	"github.com/Omochice/brakeman-to-codequality/brakeman"
 	"github.com/Omochice/brakeman-to-codequality/cli"
-	"github.com/Omochice/brakeman-to-codequality/codequality"
 	"github.com/Omochice/brakeman-to-codequality/converter"
+	"github.com/Omochice/brakeman-to-codequality/pipeline"
 )
 
 var version = "develop"
 
+// Exit statuses other than 0 (success) and 1 (error), kept distinct so that pipelines can tell them apart.
+const (
+	// exitFailOn is returned when a violation meets the --fail-on threshold.
+	exitFailOn = 2
+	// exitStrict is returned when --strict is set and warnings had to be skipped.
+	exitStrict = 3
+	// exitBrakeman is returned when Brakeman started by the run subcommand fails without a report.
+	exitBrakeman = 4
+)
+
+// Brakeman exit statuses that still come with a complete report.
+const (
+	brakemanWarningsFound = 3
+	brakemanErrorsFound   = 7
+)
+
+// brakemanError reports that Brakeman exited with a status that means it did not produce a report.
+type brakemanError struct {
+	status int
+}
+
+func (e *brakemanError) Error() string {
+	return fmt.Sprintf("brakeman exited with status %d", e.status)
+}
+
 func handleError(w io.Writer, err error) int {
 	fmt.Fprintf(w, "Error: %v\n", err)
 	return 1
 }
 
+// load opens path and decodes it with parse.
+func load[T any](path string, parse func(io.Reader) (T, error)) (T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	defer f.Close()
+
+	v, err := parse(f)
+	if err != nil {
+		return v, fmt.Errorf("%s: %w", path, err)
+	}
+	return v, nil
+}
+
 func command(args []string, inout *cli.ProcInout) int {
-	opts, err := cli.Parse(args)
+	parse := cli.Parse
+	if len(args) > 0 && args[0] == "run" {
+		parse = cli.ParseRun
+		args = args[1:]
+	}
+
+	opts, err := parse(args)
 	if err != nil {
 		var helpErr *cli.HelpError
 		if errors.As(err, &helpErr) {
@@ -36,32 +86,206 @@ func command(args []string, inout *cli.ProcInout) int {
 		return 0
 	}
 
-	var reader io.Reader
-	if opts.Source == "-" {
-		reader = inout.Stdin
+	options, err := pipelineOptions(opts, inout)
+	if err != nil {
+		return handleError(inout.Stderr, err)
+	}
+
+	ctx := context.Background()
+	var result pipeline.Result
+	if opts.Stream {
+		result, err = stream(ctx, opts.Sources[0], inout, options)
 	} else {
-		f, err := os.Open(opts.Source)
+		var reports []*brakeman.Report
+		if opts.Run {
+			reports, err = runBrakeman(ctx, opts, inout.Stderr)
+		} else {
+			reports, err = readReports(opts, inout.Stdin)
+		}
+		var brakemanErr *brakemanError
+		if errors.As(err, &brakemanErr) {
+			handleError(inout.Stderr, err)
+			return exitBrakeman
+		}
 		if err != nil {
 			return handleError(inout.Stderr, err)
 		}
-		defer f.Close()
-		reader = f
+		result, err = pipeline.ConvertReports(ctx, reports, inout.Stdout, options...)
 	}
 
-	report, err := brakeman.Parse(reader)
+	if len(result.Skipped) > 0 {
+		printSkips(inout.Stderr, result.Skipped)
+	}
+	if errors.Is(err, pipeline.ErrSkipped) {
+		return exitStrict
+	}
 	if err != nil {
 		return handleError(inout.Stderr, err)
 	}
 
-	violations := converter.Warnings(report.Warnings)
+	if opts.ShowFixed {
+		for _, fixed := range result.Fixed {
+			fmt.Fprintf(inout.Stderr, "Fixed: %s:%d %s: %s\n", fixed.Location.Path, fixed.Location.Lines.Begin, fixed.CheckName, fixed.Description)
+		}
+	}
 
-	if err := codequality.Write(violations, inout.Stdout); err != nil {
-		return handleError(inout.Stderr, err)
+	if result.Failed {
+		return exitFailOn
 	}
 
 	return 0
 }
 
+// pipelineOptions translates opts into pipeline options, loading the files they refer to.
+func pipelineOptions(opts *cli.Options, inout *cli.ProcInout) ([]pipeline.Option, error) {
+	paths := converter.PathRules{
+		Root:        opts.PathRoot,
+		StripPrefix: opts.StripPrefix,
+		Prefix:      opts.PathPrefix,
+	}
+	for _, rule := range opts.PathRewrites {
+		rewrite, err := converter.ParseRewrite(rule)
+		if err != nil {
+			return nil, err
+		}
+		paths.Rewrites = append(paths.Rewrites, rewrite)
+	}
+
+	options := []pipeline.Option{
+		pipeline.WithFormat(pipeline.Format(opts.Format)),
+		pipeline.WithAnalyzerVersion(version),
+		pipeline.WithFingerprint(converter.FingerprintMode(opts.Fingerprint)),
+		pipeline.WithPaths(paths),
+		pipeline.WithFailOn(opts.FailOn),
+	}
+	if opts.PrefixAppPath {
+		options = append(options, pipeline.WithPrefixAppPath())
+	}
+	if opts.IgnoreFile != "" {
+		ignore, err := load(opts.IgnoreFile, brakeman.ParseIgnore)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, pipeline.WithIgnore(ignore, converter.IgnoreAction(opts.IgnoreAction)))
+	}
+	if opts.SeverityConfig != "" {
+		severities, err := load(opts.SeverityConfig, converter.ParseSeverityConfig)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, pipeline.WithSeverities(severities))
+	}
+	if opts.Strict {
+		options = append(options, pipeline.WithStrict())
+	}
+	if opts.IncludeErrors {
+		options = append(options, pipeline.WithErrors())
+	}
+	if opts.IncludeObsolete {
+		options = append(options, pipeline.WithObsolete(opts.IgnoreFile))
+	}
+	if colorful(inout.Stdout) {
+		options = append(options, pipeline.WithColor())
+	}
+	if opts.Summary {
+		options = append(options, pipeline.WithSummary(inout.Stderr, colorful(inout.Stderr)))
+	}
+	if opts.Baseline != "" {
+		// The baseline is converted with every option but itself.
+		baseline, err := load(opts.Baseline, func(r io.Reader) (*converter.Baseline, error) {
+			return pipeline.ParseBaseline(r, options...)
+		})
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, pipeline.WithBaseline(baseline))
+	}
+	return options, nil
+}
+
+// colorful reports whether w is a terminal and colors are not disabled with NO_COLOR.
+func colorful(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// readReports parses every source given on the command line.
+func readReports(opts *cli.Options, stdin io.Reader) ([]*brakeman.Report, error) {
+	reports := make([]*brakeman.Report, 0, len(opts.Sources))
+	for _, source := range opts.Sources {
+		var report *brakeman.Report
+		var err error
+		if source == "-" {
+			report, err = brakeman.Parse(stdin)
+		} else {
+			report, err = load(source, brakeman.Parse)
+		}
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// runBrakeman runs Brakeman with JSON output and parses its report.
+// Brakeman's progress messages go to stderr.
+func runBrakeman(ctx context.Context, opts *cli.Options, stderr io.Writer) ([]*brakeman.Report, error) {
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, opts.Brakeman, append([]string{"-f", "json"}, opts.BrakemanArgs...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, err
+		}
+		// Brakeman signals found warnings and errors with its exit status;
+		// those are conveyed by the converted output instead.
+		if status := exitErr.ExitCode(); status != brakemanWarningsFound && status != brakemanErrorsFound {
+			return nil, &brakemanError{status: status}
+		}
+	}
+
+	report, err := brakeman.Parse(&stdout)
+	if err != nil {
+		return nil, fmt.Errorf("brakeman output: %w", err)
+	}
+	return []*brakeman.Report{report}, nil
+}
+
+// stream converts source one warning at a time.
+func stream(ctx context.Context, source string, inout *cli.ProcInout, options []pipeline.Option) (pipeline.Result, error) {
+	reader := inout.Stdin
+	if source != "-" {
+		f, err := os.Open(source)
+		if err != nil {
+			return pipeline.Result{}, err
+		}
+		defer f.Close()
+		reader = f
+	}
+	return pipeline.Convert(ctx, reader, inout.Stdout, append(options, pipeline.WithStream())...)
+}
+
+func printSkips(w io.Writer, skips []converter.Skip) {
+	fmt.Fprintf(w, "Skipped %d warning(s) lacking required fields:\n", len(skips))
+	for _, skip := range skips {
+		fmt.Fprintf(w, "  %s\n", skip)
+	}
+}
+
 func main() {
 	cli.Run(command)
 }
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -116,6 +118,615 @@ func TestEndToEnd(t *testing.T) {
 		}
 	})
 
+	t.Run("writes SARIF when format is sarif", func(t *testing.T) {
+		input := `{"scan_info":{"brakeman_version":"6.1.0"},"warnings":[{"warning_type":"SQL Injection","warning_code":0,"check_name":"SQL","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"abc123"}]}`
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--format", "sarif", "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+
+		var result map[string]any
+		if err := json.NewDecoder(&stdout).Decode(&result); err != nil {
+			t.Fatalf("failed to decode output as JSON: %v", err)
+		}
+		if result["version"] != "2.1.0" {
+			t.Fatalf("got %v, want %v", result["version"], "2.1.0")
+		}
+		if !strings.Contains(fmt.Sprint(result["runs"]), "BRAKE0000") {
+			t.Fatalf("expected %v to contain %q", result["runs"], "BRAKE0000")
+		}
+	})
+
+	t.Run("writes GitLab SAST report when format is gitlab-sast", func(t *testing.T) {
+		input := `{"scan_info":{"brakeman_version":"6.1.0"},"warnings":[{"warning_type":"SQL Injection","warning_code":0,"message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","cwe_id":[89],"fingerprint":"abc123"}]}`
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--format", "gitlab-sast", "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+
+		var result struct {
+			Scan struct {
+				Scanner struct {
+					Version string `json:"version"`
+				} `json:"scanner"`
+			} `json:"scan"`
+			Vulnerabilities []map[string]any `json:"vulnerabilities"`
+		}
+		if err := json.NewDecoder(&stdout).Decode(&result); err != nil {
+			t.Fatalf("failed to decode output as JSON: %v", err)
+		}
+		if result.Scan.Scanner.Version != "6.1.0" {
+			t.Fatalf("got %v, want %v", result.Scan.Scanner.Version, "6.1.0")
+		}
+		if len(result.Vulnerabilities) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(result.Vulnerabilities))
+		}
+	})
+
+	t.Run("writes Checkstyle XML when format is checkstyle", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"SQL Injection","check_name":"SQL","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"}]}`
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--format", "checkstyle", "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+
+		want := `<error line="42" severity="error" message="Possible SQL injection" source="brakeman.SQL"></error>`
+		if !strings.Contains(stdout.String(), want) {
+			t.Fatalf("expected %q to contain %q", stdout.String(), want)
+		}
+	})
+
+	t.Run("writes JUnit XML when format is junit", func(t *testing.T) {
+		input := `{"scan_info":{"checks_performed":["SQL","Redirect"]},"warnings":[{"warning_type":"SQL Injection","check_name":"SQL","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"}]}`
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--format", "junit", "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+
+		want := `<testsuites name="brakeman" tests="2" failures="1">`
+		if !strings.Contains(stdout.String(), want) {
+			t.Fatalf("expected %q to contain %q", stdout.String(), want)
+		}
+	})
+
+	t.Run("writes GitHub Actions annotations when format is github-actions", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"}]}`
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--format", "github-actions", "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+
+		want := "::error file=app/models/user.rb,line=42,title=SQL Injection::Possible SQL injection\n"
+		if stdout.String() != want {
+			t.Fatalf("got %q, want %q", stdout.String(), want)
+		}
+	})
+
+	t.Run("writes reviewdog diagnostics when format is rdjsonl", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"},{"warning_type":"Redirect","message":"Possible unprotected redirect","file":"app/controllers/users_controller.rb","line":7,"confidence":"Weak","fingerprint":"fp2"}]}`
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--format", "rdjsonl", "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+
+		lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("got %d lines, want 2", len(lines))
+		}
+		var diagnostic struct {
+			Severity string `json:"severity"`
+			Source   struct {
+				Name string `json:"name"`
+			} `json:"source"`
+		}
+		if err := json.Unmarshal([]byte(lines[1]), &diagnostic); err != nil {
+			t.Fatalf("failed to decode line as JSON: %v", err)
+		}
+		if diagnostic.Severity != "INFO" || diagnostic.Source.Name != "brakeman" {
+			t.Fatalf("got %+v, want INFO from brakeman", diagnostic)
+		}
+	})
+
+	t.Run("writes SonarQube generic issues when format is sonarqube", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"SQL Injection","warning_code":0,"message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"}]}`
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--format", "sonarqube", "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+
+		var report struct {
+			Rules  []map[string]any `json:"rules"`
+			Issues []struct {
+				EngineID string `json:"engineId"`
+				RuleID   string `json:"ruleId"`
+				Severity string `json:"severity"`
+			} `json:"issues"`
+		}
+		if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+			t.Fatalf("failed to decode output as JSON: %v", err)
+		}
+		if len(report.Rules) != 1 || len(report.Issues) != 1 {
+			t.Fatalf("got %d rules and %d issues, want 1 each", len(report.Rules), len(report.Issues))
+		}
+		if issue := report.Issues[0]; issue.EngineID != "brakeman" || issue.RuleID != "BRAKE0000" || issue.Severity != "CRITICAL" {
+			t.Fatalf("got %+v", issue)
+		}
+	})
+
+	t.Run("writes findings for the terminal when format is text", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","code":"User.where(...)","fingerprint":"fp1"}]}`
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--format", "text", "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+
+		output := stdout.String()
+		for _, want := range []string{"app/models/user.rb\n", "42  critical  SQL Injection: Possible SQL injection", "User.where(...)", "Total"} {
+			if !strings.Contains(output, want) {
+				t.Fatalf("expected %q to contain %q", output, want)
+			}
+		}
+		if strings.Contains(output, "\x1b[") {
+			t.Fatalf("expected no escape sequences when not writing to a terminal, got %q", output)
+		}
+	})
+
+	t.Run("prints a summary on stderr alongside the output", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"}]}`
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--summary", "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+
+		var violations []map[string]any
+		if err := json.Unmarshal(stdout.Bytes(), &violations); err != nil {
+			t.Fatalf("failed to decode output as JSON: %v", err)
+		}
+		if !strings.HasPrefix(stderr.String(), "Warning type") || !strings.Contains(stderr.String(), "SQL Injection") {
+			t.Fatalf("got %q, want a table of totals", stderr.String())
+		}
+	})
+
+	t.Run("drops warnings listed in the ignore file", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"},{"warning_type":"XSS","message":"Cross-site scripting","file":"app/views/index.erb","line":10,"confidence":"Medium","fingerprint":"fp2"}]}`
+		ignore := `{"ignored_warnings":[{"fingerprint":"fp1","note":"False positive"}]}`
+		path := filepath.Join(t.TempDir(), "brakeman.ignore")
+		if err := os.WriteFile(path, []byte(ignore), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--ignore-file", path, "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+
+		var result []map[string]any
+		if err := json.NewDecoder(&stdout).Decode(&result); err != nil {
+			t.Fatalf("failed to decode output as JSON: %v", err)
+		}
+		if len(result) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(result))
+		}
+		if result[0]["fingerprint"] != "fp2" {
+			t.Fatalf("got %v, want %v", result[0]["fingerprint"], "fp2")
+		}
+	})
+
+	t.Run("returns non-zero exit code when ignore file does not exist", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(`{"warnings":[]}`),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		missingFile := filepath.Join(t.TempDir(), "brakeman.ignore")
+		exitCode := command([]string{"--ignore-file", missingFile, "-"}, inout)
+		if exitCode != 1 {
+			t.Fatalf("got %v, want %v", exitCode, 1)
+		}
+	})
+
+	t.Run("applies the severity config", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"Weak","fingerprint":"fp1"}]}`
+		config := "rules:\n  - warning_type: SQL Injection\n    severity: blocker\n"
+		path := filepath.Join(t.TempDir(), "severity.yml")
+		if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--severity-config", path, "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), `"severity":"blocker"`) {
+			t.Fatalf("expected %q to contain %q", stdout.String(), `"severity":"blocker"`)
+		}
+	})
+
+	t.Run("returns non-zero exit code for invalid severity config", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "severity.yml")
+		if err := os.WriteFile(path, []byte("confidence:\n  high: severe\n"), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(`{"warnings":[]}`),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--severity-config", path, "-"}, inout)
+		if exitCode != 1 {
+			t.Fatalf("got %v, want %v", exitCode, 1)
+		}
+		if !strings.Contains(stderr.String(), "severe") {
+			t.Fatalf("expected %q to contain %q", stderr.String(), "severe")
+		}
+	})
+
+	t.Run("returns fail-on exit code when a violation meets the threshold", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"}]}`
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--fail-on", "major", "-"}, inout)
+		if exitCode != 2 {
+			t.Fatalf("got %v, want %v", exitCode, 2)
+		}
+		if !strings.Contains(stdout.String(), "Possible SQL injection") {
+			t.Fatalf("expected %q to contain %q", stdout.String(), "Possible SQL injection")
+		}
+	})
+
+	t.Run("returns zero when no violation meets the fail-on threshold", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"Weak","fingerprint":"fp1"}]}`
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--fail-on", "major", "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+	})
+
+	t.Run("reports only warnings missing from the baseline", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"},{"warning_type":"XSS","message":"Cross-site scripting","file":"app/views/index.erb","line":10,"confidence":"Medium","fingerprint":"fp2"}]}`
+		baseline := `[{"description":"Possible SQL injection","check_name":"SQL Injection","fingerprint":"fp1","severity":"critical","location":{"path":"app/models/user.rb","lines":{"begin":42}}},{"description":"Possible unprotected redirect","check_name":"Redirect","fingerprint":"fp3","severity":"critical","location":{"path":"app/controllers/users_controller.rb","lines":{"begin":7}}}]`
+		path := filepath.Join(t.TempDir(), "baseline.json")
+		if err := os.WriteFile(path, []byte(baseline), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--baseline", path, "--show-fixed", "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+
+		var result []map[string]any
+		if err := json.NewDecoder(&stdout).Decode(&result); err != nil {
+			t.Fatalf("failed to decode output as JSON: %v", err)
+		}
+		if len(result) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(result))
+		}
+		if result[0]["fingerprint"] != "fp2" {
+			t.Fatalf("got %v, want %v", result[0]["fingerprint"], "fp2")
+		}
+
+		want := "Fixed: app/controllers/users_controller.rb:7 Redirect: Possible unprotected redirect\n"
+		if stderr.String() != want {
+			t.Fatalf("got %q, want %q", stderr.String(), want)
+		}
+	})
+
+	t.Run("rewrites warning paths", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"/app/engines/api/app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"}]}`
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--path-root", "/app", "--path-rewrite", "^engines/=packs/", "--path-prefix", "services", "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), `"path":"services/packs/api/app/models/user.rb"`) {
+			t.Fatalf("expected %q to contain %q", stdout.String(), `"path":"services/packs/api/app/models/user.rb"`)
+		}
+	})
+
+	t.Run("returns non-zero exit code for invalid path rewrite", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(`{"warnings":[]}`),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--path-rewrite", "(engines", "-"}, inout)
+		if exitCode != 1 {
+			t.Fatalf("got %v, want %v", exitCode, 1)
+		}
+	})
+
+	t.Run("computes fingerprints when requested", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High"}]}`
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--fingerprint", "computed", "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+
+		var result []map[string]any
+		if err := json.NewDecoder(&stdout).Decode(&result); err != nil {
+			t.Fatalf("failed to decode output as JSON: %v", err)
+		}
+		if len(result) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(result))
+		}
+		if fingerprint, _ := result[0]["fingerprint"].(string); len(fingerprint) != 64 {
+			t.Fatalf("expected a SHA-256 fingerprint, got %v", result[0]["fingerprint"])
+		}
+	})
+
+	t.Run("reports skipped warnings on stderr", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"},{"warning_type":"Redirect","message":"Possible unprotected redirect","file":"app/controllers/users_controller.rb","confidence":"High","fingerprint":"fp2"}]}`
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+		if !strings.Contains(stderr.String(), "Skipped 1 warning(s)") {
+			t.Fatalf("expected %q to contain %q", stderr.String(), "Skipped 1 warning(s)")
+		}
+		if !strings.Contains(stderr.String(), "warning #2 (Redirect at app/controllers/users_controller.rb:0): missing line") {
+			t.Fatalf("expected %q to describe the skipped warning", stderr.String())
+		}
+		if !strings.Contains(stdout.String(), "Possible SQL injection") {
+			t.Fatalf("expected %q to contain %q", stdout.String(), "Possible SQL injection")
+		}
+	})
+
+	t.Run("returns strict exit code when warnings are skipped", func(t *testing.T) {
+		input := `{"warnings":[{"warning_type":"Redirect","message":"Possible unprotected redirect","file":"app/controllers/users_controller.rb","confidence":"High","fingerprint":"fp2"}]}`
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--strict", "-"}, inout)
+		if exitCode != 3 {
+			t.Fatalf("got %v, want %v", exitCode, 3)
+		}
+		if stdout.String() != "" {
+			t.Fatalf("expected empty stdout, got %q", stdout.String())
+		}
+	})
+
+	t.Run("includes Brakeman errors and obsolete ignore entries when requested", func(t *testing.T) {
+		input := `{"warnings":[],"errors":[{"error":"syntax error, unexpected end-of-input Could not parse app/views/users/edit.html.erb","location":"/usr/local/bundle/gems/ruby_parser-3.21.0/lib/ruby_parser_extras.rb:1218:in 'on_error'"},{"error":"undefined method 'each' for nil","location":"/usr/local/bundle/gems/brakeman-6.1.0/lib/brakeman/checks/check_sql.rb:42:in 'process'"}],"obsolete":["abc123"]}`
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(input),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--include-errors", "--include-obsolete", "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+
+		var result []map[string]any
+		if err := json.NewDecoder(&stdout).Decode(&result); err != nil {
+			t.Fatalf("failed to decode output as JSON: %v", err)
+		}
+		if len(result) != 2 {
+			t.Fatalf("expected length %d, got %d", 2, len(result))
+		}
+		if result[0]["location"].(map[string]any)["path"] != "app/views/users/edit.html.erb" {
+			t.Fatalf("unexpected location: %v", result[0]["location"])
+		}
+		if result[1]["location"].(map[string]any)["path"] != "config/brakeman.ignore" {
+			t.Fatalf("unexpected location: %v", result[1]["location"])
+		}
+	})
+
+	t.Run("reports obsolete entries on the given ignore file without rewriting it", func(t *testing.T) {
+		dir := t.TempDir()
+		ignoreFile := filepath.Join(dir, "brakeman.ignore")
+		if err := os.WriteFile(ignoreFile, []byte(`{"ignored_warnings":[]}`), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(`{"warnings":[],"obsolete":["abc123"]}`),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--path-prefix", "services/api", "--ignore-file", ignoreFile, "--include-obsolete", "-"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+
+		var result []map[string]any
+		if err := json.NewDecoder(&stdout).Decode(&result); err != nil {
+			t.Fatalf("failed to decode output as JSON: %v", err)
+		}
+		if result[0]["location"].(map[string]any)["path"] != filepath.ToSlash(ignoreFile) {
+			t.Fatalf("unexpected location: %v", result[0]["location"])
+		}
+	})
+
+	t.Run("streams the same output as the buffered conversion", func(t *testing.T) {
+		input := `{"scan_info":{"brakeman_version":"6.1.0"},"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","code":"User.where(...)","fingerprint":"fp1"},{"warning_type":"Redirect","message":"Possible unprotected redirect","file":"app/controllers/users_controller.rb","confidence":"High","fingerprint":"fp2"},{"warning_type":"XSS","message":"Cross-site scripting","file":"app/views/index.erb","line":10,"confidence":"Medium","fingerprint":"fp3"}],"errors":[{"error":"syntax error, unexpected end-of-input Could not parse app/views/users/edit.html.erb","location":"/usr/local/bundle/gems/ruby_parser-3.21.0/lib/ruby_parser_extras.rb:1218:in 'on_error'"},{"error":"undefined method 'each' for nil","location":"/usr/local/bundle/gems/brakeman-6.1.0/lib/brakeman/checks/check_sql.rb:42:in 'process'"}]}`
+
+		run := func(args ...string) (int, string, string) {
+			var stdout, stderr bytes.Buffer
+			inout := &cli.ProcInout{
+				Stdin:  strings.NewReader(input),
+				Stdout: &stdout,
+				Stderr: &stderr,
+			}
+			exitCode := command(append(args, "--include-errors", "--fail-on", "critical", "-"), inout)
+			return exitCode, stdout.String(), stderr.String()
+		}
+
+		wantCode, wantStdout, wantStderr := run()
+		gotCode, gotStdout, gotStderr := run("--stream")
+		if gotCode != wantCode {
+			t.Fatalf("got %v, want %v", gotCode, wantCode)
+		}
+		if gotStdout != wantStdout {
+			t.Fatalf("got %q, want %q", gotStdout, wantStdout)
+		}
+		if gotStderr != wantStderr {
+			t.Fatalf("got %q, want %q", gotStderr, wantStderr)
+		}
+	})
+
+	t.Run("returns non-zero exit code for invalid JSON when streaming", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(`{"warnings":[{invalid json`),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--stream", "-"}, inout)
+		if exitCode != 1 {
+			t.Fatalf("got %v, want %v", exitCode, 1)
+		}
+	})
+
 	t.Run("returns non-zero exit code for invalid JSON from stdin", func(t *testing.T) {
 		var stdout, stderr bytes.Buffer
 		inout := &cli.ProcInout{
@@ -147,7 +758,106 @@ func TestEndToEnd(t *testing.T) {
 		}
 	})
 
-	t.Run("returns non-zero exit code when multiple arguments given", func(t *testing.T) {
+	t.Run("merges multiple reports into one output", func(t *testing.T) {
+		dir := t.TempDir()
+		reports := map[string]string{
+			"api.json":     `{"scan_info":{"app_path":"` + filepath.ToSlash(filepath.Join(dir, "engines", "api")) + `"},"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"}]}`,
+			"billing.json": `{"scan_info":{"app_path":"engines/billing"},"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"},{"warning_type":"XSS","message":"Cross-site scripting","file":"app/views/index.erb","line":10,"confidence":"Medium","fingerprint":"fp2"}]}`,
+		}
+		for name, content := range reports {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+		}
+		t.Chdir(dir)
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(""),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--prefix-app-path", "api.json", "billing.json"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+
+		var result []map[string]any
+		if err := json.NewDecoder(&stdout).Decode(&result); err != nil {
+			t.Fatalf("failed to decode output as JSON: %v", err)
+		}
+		if len(result) != 3 {
+			t.Fatalf("expected length %d, got %d", 3, len(result))
+		}
+		paths := []any{result[0]["location"].(map[string]any)["path"], result[1]["location"].(map[string]any)["path"], result[2]["location"].(map[string]any)["path"]}
+		if paths[0] != "engines/api/app/models/user.rb" || paths[1] != "engines/billing/app/models/user.rb" || paths[2] != "engines/billing/app/views/index.erb" {
+			t.Fatalf("unexpected paths: %v", paths)
+		}
+		if result[0]["fingerprint"] == result[1]["fingerprint"] {
+			t.Fatalf("expected the findings of both engines to have distinct fingerprints, got %v", result[0]["fingerprint"])
+		}
+	})
+
+	t.Run("does not report Brakeman errors and obsolete entries already in the baseline", func(t *testing.T) {
+		dir := t.TempDir()
+		report := `{"warnings":[],"errors":[{"error":"syntax error Could not parse app/views/users/edit.html.erb","location":"/usr/local/bundle/gems/ruby_parser-3.21.0/lib/ruby_parser_extras.rb:1218:in 'on_error'"}],"obsolete":["abc123"]}`
+		if err := os.WriteFile(filepath.Join(dir, "s.json"), []byte(report), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+		t.Chdir(dir)
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(""),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--include-errors", "--include-obsolete", "--baseline", "s.json", "s.json"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+		if strings.TrimSpace(stdout.String()) != "[]" {
+			t.Fatalf("got %q, want %q", stdout.String(), "[]")
+		}
+	})
+
+	t.Run("rebases a Brakeman baseline when prefixing app paths", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(dir, "engines", "a"), 0o755); err != nil {
+			t.Fatalf("failed to create test directory: %v", err)
+		}
+		report := `{"scan_info":{"app_path":"engines/a"},"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"}]}`
+		if err := os.WriteFile(filepath.Join(dir, "engines", "a", "r.json"), []byte(report), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+		t.Chdir(dir)
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(""),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--prefix-app-path", "--baseline", "engines/a/r.json", "engines/a/r.json"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+		if strings.TrimSpace(stdout.String()) != "[]" {
+			t.Fatalf("got %q, want %q", stdout.String(), "[]")
+		}
+	})
+
+	t.Run("reports obsolete entries on the ignore file of each application", func(t *testing.T) {
+		dir := t.TempDir()
+		report := `{"scan_info":{"app_path":"engines/a"},"warnings":[],"obsolete":["abc123"]}`
+		if err := os.WriteFile(filepath.Join(dir, "r.json"), []byte(report), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+		t.Chdir(dir)
+
 		var stdout, stderr bytes.Buffer
 		inout := &cli.ProcInout{
 			Stdin:  strings.NewReader(""),
@@ -155,7 +865,32 @@ func TestEndToEnd(t *testing.T) {
 			Stderr: &stderr,
 		}
 
-		exitCode := command([]string{"a.json", "b.json"}, inout)
+		exitCode := command([]string{"--prefix-app-path", "--include-obsolete", "r.json"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+
+		var result []map[string]any
+		if err := json.NewDecoder(&stdout).Decode(&result); err != nil {
+			t.Fatalf("failed to decode output as JSON: %v", err)
+		}
+		if len(result) != 1 {
+			t.Fatalf("expected length %d, got %d", 1, len(result))
+		}
+		if path := result[0]["location"].(map[string]any)["path"]; path != "engines/a/config/brakeman.ignore" {
+			t.Fatalf("got %v, want %v", path, "engines/a/config/brakeman.ignore")
+		}
+	})
+
+	t.Run("returns non-zero exit code when app path is missing for prefixing", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{
+			Stdin:  strings.NewReader(`{"warnings":[]}`),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+
+		exitCode := command([]string{"--prefix-app-path", "-"}, inout)
 		if exitCode != 1 {
 			t.Fatalf("got %v, want %v", exitCode, 1)
 		}
@@ -176,3 +911,135 @@ func TestEndToEnd(t *testing.T) {
 		}
 	})
 }
+
+// fakeBrakeman puts an executable named brakeman running script first on PATH.
+// The arguments it receives are written to the returned file.
+func fakeBrakeman(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake brakeman is a shell script")
+	}
+
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args")
+	content := fmt.Sprintf("#!/bin/sh\nprintf '%%s\\n' \"$@\" > %q\n%s\n", argsFile, script)
+	if err := os.WriteFile(filepath.Join(dir, "brakeman"), []byte(content), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return argsFile
+}
+
+func TestRun(t *testing.T) {
+	report := `{"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"}]}`
+
+	t.Run("converts the report of Brakeman with JSON output", func(t *testing.T) {
+		argsFile := fakeBrakeman(t, fmt.Sprintf("echo 'scanning' >&2\necho '%s'\nexit 3", report))
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{Stdin: strings.NewReader(""), Stdout: &stdout, Stderr: &stderr}
+
+		exitCode := command([]string{"run", "--", "--no-pager", "app"}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), "Possible SQL injection") {
+			t.Fatalf("expected %q to contain %q", stdout.String(), "Possible SQL injection")
+		}
+		if stderr.String() != "scanning\n" {
+			t.Fatalf("got %q, want %q", stderr.String(), "scanning\n")
+		}
+
+		args, err := os.ReadFile(argsFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(args) != "-f\njson\n--no-pager\napp\n" {
+			t.Fatalf("got %q, want %q", args, "-f\njson\n--no-pager\napp\n")
+		}
+	})
+
+	t.Run("applies conversion options", func(t *testing.T) {
+		fakeBrakeman(t, fmt.Sprintf("echo '%s'", report))
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{Stdin: strings.NewReader(""), Stdout: &stdout, Stderr: &stderr}
+
+		exitCode := command([]string{"run", "--fail-on", "critical"}, inout)
+		if exitCode != exitFailOn {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, exitFailOn, stderr.String())
+		}
+	})
+
+	t.Run("runs the configured Brakeman executable", func(t *testing.T) {
+		fakeBrakeman(t, "exit 1")
+		dir := t.TempDir()
+		path := filepath.Join(dir, "my-brakeman")
+		if err := os.WriteFile(path, []byte(fmt.Sprintf("#!/bin/sh\necho '%s'\n", report)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{Stdin: strings.NewReader(""), Stdout: &stdout, Stderr: &stderr}
+
+		exitCode := command([]string{"run", "--brakeman", path}, inout)
+		if exitCode != 0 {
+			t.Fatalf("got %v, want %v\nstderr: %s", exitCode, 0, stderr.String())
+		}
+	})
+
+	t.Run("returns Brakeman exit code when Brakeman fails", func(t *testing.T) {
+		fakeBrakeman(t, "echo 'Please supply the path to a Rails application' >&2\nexit 4")
+
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{Stdin: strings.NewReader(""), Stdout: &stdout, Stderr: &stderr}
+
+		exitCode := command([]string{"run"}, inout)
+		if exitCode != exitBrakeman {
+			t.Fatalf("got %v, want %v", exitCode, exitBrakeman)
+		}
+		if !strings.Contains(stderr.String(), "status 4") {
+			t.Fatalf("expected %q to contain %q", stderr.String(), "status 4")
+		}
+		if stdout.String() != "" {
+			t.Fatalf("expected empty string, got %q", stdout.String())
+		}
+	})
+
+	t.Run("returns non-zero exit code when Brakeman is not found", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		inout := &cli.ProcInout{Stdin: strings.NewReader(""), Stdout: &stdout, Stderr: &stderr}
+
+		exitCode := command([]string{"run", "--brakeman", filepath.Join(t.TempDir(), "missing")}, inout)
+		if exitCode != 1 {
+			t.Fatalf("got %v, want %v", exitCode, 1)
+		}
+	})
+}
+
+func TestColorful(t *testing.T) {
+	t.Run("returns false for writers other than files", func(t *testing.T) {
+		if colorful(&bytes.Buffer{}) {
+			t.Fatal("expected false for a buffer")
+		}
+	})
+
+	t.Run("returns false for files that are not terminals", func(t *testing.T) {
+		f, err := os.Create(filepath.Join(t.TempDir(), "output"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		if colorful(f) {
+			t.Fatal("expected false for a regular file")
+		}
+	})
+
+	t.Run("returns false when NO_COLOR is set", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		if colorful(os.Stdout) {
+			t.Fatal("expected false with NO_COLOR")
+		}
+	})
+}
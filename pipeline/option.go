@@ -0,0 +1,200 @@
+package pipeline
+
+import (
+	"io"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/codequality"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+)
+
+// Format selects the output written by Convert.
+type Format string
+
+const (
+	// FormatCodeQuality writes GitLab Code Quality JSON.
+	FormatCodeQuality Format = "codequality"
+	// FormatSARIF writes SARIF 2.1.0.
+	FormatSARIF Format = "sarif"
+	// FormatGitLabSAST writes a GitLab SAST report.
+	FormatGitLabSAST Format = "gitlab-sast"
+	// FormatCheckstyle writes Checkstyle XML.
+	FormatCheckstyle Format = "checkstyle"
+	// FormatJUnit writes JUnit XML.
+	FormatJUnit Format = "junit"
+	// FormatGitHubActions writes GitHub Actions workflow commands that annotate the reported lines.
+	FormatGitHubActions Format = "github-actions"
+	// FormatRDJSON writes the reviewdog Diagnostic Format.
+	FormatRDJSON Format = "rdjson"
+	// FormatRDJSONL writes the reviewdog Diagnostic Format as JSON Lines, one diagnostic per line.
+	FormatRDJSONL Format = "rdjsonl"
+	// FormatSonarQube writes SonarQube Generic Issue Data.
+	FormatSonarQube Format = "sonarqube"
+	// FormatText writes findings grouped by file and a table of totals for people to read.
+	FormatText Format = "text"
+)
+
+// Option customizes a conversion.
+type Option func(*config)
+
+type config struct {
+	converter       converter.Converter
+	format          Format
+	analyzerVersion string
+	failOn          string
+	strict          bool
+	streaming       bool
+	includeErrors   bool
+	includeObsolete bool
+	ignoreFile      string
+	prefixAppPath   bool
+	color           bool
+	summary         io.Writer
+	summaryColor    bool
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{
+		format:          FormatCodeQuality,
+		analyzerVersion: "develop",
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithFormat selects the output format. The default is FormatCodeQuality.
+func WithFormat(format Format) Option {
+	return func(c *config) {
+		c.format = format
+	}
+}
+
+// WithAnalyzerVersion sets the analyzer version reported by FormatGitLabSAST.
+func WithAnalyzerVersion(version string) Option {
+	return func(c *config) {
+		c.analyzerVersion = version
+	}
+}
+
+// WithIgnore excludes the warnings listed in a brakeman.ignore file, as selected by action.
+func WithIgnore(ignore *brakeman.Ignore, action converter.IgnoreAction) Option {
+	return func(c *config) {
+		c.converter.Ignore = ignore
+		c.converter.IgnoreAction = action
+	}
+}
+
+// WithSeverities overrides the default confidence based severity mapping.
+func WithSeverities(severities *converter.SeverityConfig) Option {
+	return func(c *config) {
+		c.converter.Severities = severities
+	}
+}
+
+// WithFingerprint selects how fingerprints are produced.
+func WithFingerprint(mode converter.FingerprintMode) Option {
+	return func(c *config) {
+		c.converter.Fingerprint = mode
+	}
+}
+
+// WithPaths rewrites every reported location.
+func WithPaths(rules converter.PathRules) Option {
+	return func(c *config) {
+		c.converter.Paths = rules
+	}
+}
+
+// WithBaseline reports only warnings absent from baseline, and lists the
+// baseline violations that are gone in Result.Fixed. Use ParseBaseline to
+// read a baseline converted with the same options.
+func WithBaseline(baseline *converter.Baseline) Option {
+	return func(c *config) {
+		c.converter.Baseline = baseline
+	}
+}
+
+// WithFailOn sets Result.Failed when a violation has at least severity,
+// which must be one of the CodeQuality severities in lower case.
+func WithFailOn(severity string) Option {
+	return func(c *config) {
+		c.failOn = severity
+	}
+}
+
+// WithStrict makes Convert fail with ErrSkipped when a warning lacks required fields.
+// Without streaming, nothing is written in that case.
+func WithStrict() Option {
+	return func(c *config) {
+		c.strict = true
+	}
+}
+
+// WithStream makes Convert read and write one warning at a time, so that
+// memory use stays flat regardless of the report size.
+// Only FormatCodeQuality supports streaming, and Result.Fixed is not computed.
+func WithStream() Option {
+	return func(c *config) {
+		c.streaming = true
+	}
+}
+
+// WithErrors reports the files Brakeman could not parse as info issues (FormatCodeQuality only).
+func WithErrors() Option {
+	return func(c *config) {
+		c.includeErrors = true
+	}
+}
+
+// WithObsolete reports obsolete ignore entries as issues on ignoreFile (FormatCodeQuality only).
+// ignoreFile is used as is; an empty ignoreFile means converter.DefaultIgnoreFile
+// within the application, with the path rules applied.
+func WithObsolete(ignoreFile string) Option {
+	return func(c *config) {
+		c.includeObsolete = true
+		c.ignoreFile = ignoreFile
+	}
+}
+
+// WithPrefixAppPath prefixes the paths of every report, and of a Brakeman
+// baseline, with its scan_info.app_path relative to the working directory.
+// Reports are rebased in place. Streaming does not support it.
+func WithPrefixAppPath() Option {
+	return func(c *config) {
+		c.prefixAppPath = true
+	}
+}
+
+// WithColor colors the severities of FormatText with ANSI escape sequences.
+func WithColor() Option {
+	return func(c *config) {
+		c.color = true
+	}
+}
+
+// WithSummary also writes a table of the findings by warning type and
+// severity to w, colored when color is set. Streaming and FormatText, which
+// already ends with the table, do not support summaries.
+func WithSummary(w io.Writer, color bool) Option {
+	return func(c *config) {
+		c.summary = w
+		c.summaryColor = color
+	}
+}
+
+// ParseBaseline reads a previous Brakeman or CodeQuality JSON report from r,
+// converting a Brakeman report with the policy opts describe, including the
+// errors and obsolete entries WithErrors and WithObsolete report.
+func ParseBaseline(r io.Reader, opts ...Option) (*converter.Baseline, error) {
+	cfg := newConfig(opts)
+	previous := cfg.converter
+	previous.Baseline = nil
+	return converter.ParseBaselineFunc(r, func(report *brakeman.Report) ([]codequality.Violation, error) {
+		if err := cfg.rebase(report); err != nil {
+			return nil, err
+		}
+		return append(previous.Warnings(report.Warnings), cfg.diagnostics(&previous, report)...), nil
+	})
+}
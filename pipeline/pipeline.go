@@ -0,0 +1,268 @@
+// Package pipeline converts Brakeman reports into the supported output formats.
+// It is what the brakeman-to-codequality command runs, for programs that
+// embed the conversion instead of invoking the command.
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/checkstyle"
+	"github.com/Omochice/brakeman-to-codequality/codequality"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+	"github.com/Omochice/brakeman-to-codequality/github"
+	"github.com/Omochice/brakeman-to-codequality/junit"
+	"github.com/Omochice/brakeman-to-codequality/rdjson"
+	"github.com/Omochice/brakeman-to-codequality/sarif"
+	"github.com/Omochice/brakeman-to-codequality/sast"
+	"github.com/Omochice/brakeman-to-codequality/sonar"
+	"github.com/Omochice/brakeman-to-codequality/text"
+)
+
+// ErrSkipped is returned with WithStrict when warnings lack required fields.
+// Result.Skipped lists them.
+var ErrSkipped = errors.New("warnings lacking required fields were skipped")
+
+// Result summarizes a conversion.
+type Result struct {
+	// Violations is the number of CodeQuality violations the report converts to.
+	Violations int
+	// Skipped lists the warnings left out because they lack required fields.
+	Skipped []converter.Skip
+	// Fixed lists the baseline violations that are no longer reported.
+	Fixed []codequality.Violation
+	// Failed reports whether a violation met the WithFailOn threshold.
+	Failed bool
+}
+
+// Convert reads a Brakeman JSON report from r and writes it to w in the selected format.
+func Convert(ctx context.Context, r io.Reader, w io.Writer, opts ...Option) (Result, error) {
+	cfg := newConfig(opts)
+	if err := cfg.validate(); err != nil {
+		return Result{}, err
+	}
+	if cfg.streaming {
+		return cfg.stream(ctx, r, w)
+	}
+
+	report, err := brakeman.Parse(r)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := cfg.rebase(report); err != nil {
+		return Result{}, err
+	}
+	return cfg.convert(ctx, report, w)
+}
+
+// ConvertReports merges reports, as brakeman.Merge does, and writes the result
+// to w in the selected format. WithStream has no effect.
+func ConvertReports(ctx context.Context, reports []*brakeman.Report, w io.Writer, opts ...Option) (Result, error) {
+	cfg := newConfig(opts)
+	if err := cfg.validate(); err != nil {
+		return Result{}, err
+	}
+	for i, report := range reports {
+		if err := cfg.rebase(report); err != nil {
+			return Result{}, fmt.Errorf("report %d: %w", i+1, err)
+		}
+	}
+	return cfg.convert(ctx, brakeman.Merge(reports...), w)
+}
+
+// validate rejects options the selected format cannot honor.
+func (cfg *config) validate() error {
+	if (cfg.includeErrors || cfg.includeObsolete) && cfg.format != FormatCodeQuality && cfg.format != "" {
+		return fmt.Errorf("Brakeman errors and obsolete entries are supported only by the %s format, got %q", FormatCodeQuality, cfg.format)
+	}
+	if cfg.summary != nil && cfg.format == FormatText {
+		return errors.New("summaries cannot be combined with the text format, which already ends with one")
+	}
+	if cfg.failOn != "" && !converter.IsSeverity(cfg.failOn) {
+		return fmt.Errorf("unknown fail-on severity %q", cfg.failOn)
+	}
+	return nil
+}
+
+// rebase prefixes the paths of report with its application root when
+// WithPrefixAppPath is given.
+func (cfg *config) rebase(report *brakeman.Report) error {
+	if !cfg.prefixAppPath {
+		return nil
+	}
+	root, err := appRoot(report)
+	if err != nil {
+		return err
+	}
+	report.Rebase(root)
+	return nil
+}
+
+// appRoot returns the location of the application scanned for report,
+// relative to the working directory.
+func appRoot(report *brakeman.Report) (string, error) {
+	appPath := report.ScanInfo.AppPath
+	if appPath == "" {
+		return "", errors.New("scan_info.app_path is missing")
+	}
+	if !filepath.IsAbs(appPath) {
+		return filepath.ToSlash(appPath), nil
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	root, err := filepath.Rel(wd, appPath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(root), nil
+}
+
+func (cfg *config) convert(ctx context.Context, report *brakeman.Report, w io.Writer) (Result, error) {
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+
+	c := &cfg.converter
+	result := Result{Skipped: c.Skipped(report.Warnings)}
+	if cfg.strict && len(result.Skipped) > 0 {
+		return result, ErrSkipped
+	}
+
+	violations := append(c.Warnings(report.Warnings), cfg.diagnostics(c, report)...)
+	result.Violations = len(violations)
+	for _, violation := range violations {
+		result.Failed = result.Failed || cfg.fails(violation)
+	}
+
+	var err error
+	switch cfg.format {
+	case FormatSARIF:
+		err = sarif.Write(c.SARIF(report), w)
+	case FormatGitLabSAST:
+		err = sast.Write(c.SAST(report, cfg.analyzerVersion), w)
+	case FormatCheckstyle:
+		err = checkstyle.Write(c.Checkstyle(report), w)
+	case FormatJUnit:
+		err = junit.Write(c.JUnit(report), w)
+	case FormatGitHubActions:
+		err = github.Write(c.Annotations(report), w)
+	case FormatRDJSON:
+		err = rdjson.Write(c.RDJSON(report), w)
+	case FormatRDJSONL:
+		err = rdjson.WriteLines(c.RDJSON(report), w)
+	case FormatSonarQube:
+		err = sonar.Write(c.Sonar(report), w)
+	case FormatText:
+		err = text.Write(c.Text(report), w, cfg.color)
+	case FormatCodeQuality, "":
+		err = codequality.Write(violations, w)
+	default:
+		err = fmt.Errorf("unknown format %q", cfg.format)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if cfg.summary != nil {
+		if err := text.WriteSummary(c.Text(report), cfg.summary, cfg.summaryColor); err != nil {
+			return result, err
+		}
+	}
+
+	if c.Baseline != nil {
+		current := *c
+		current.Baseline = nil
+		result.Fixed = c.Baseline.Fixed(append(current.Warnings(report.Warnings), cfg.diagnostics(&current, report)...))
+	}
+
+	return result, nil
+}
+
+// stream converts a single report to CodeQuality JSON one warning at a time.
+// Unlike convert, WithStrict fails after the output is written.
+func (cfg *config) stream(ctx context.Context, r io.Reader, w io.Writer) (Result, error) {
+	if cfg.format != FormatCodeQuality && cfg.format != "" {
+		return Result{}, fmt.Errorf("streaming supports only the %s format, got %q", FormatCodeQuality, cfg.format)
+	}
+	if cfg.summary != nil {
+		return Result{}, errors.New("streaming does not support summaries")
+	}
+	if cfg.prefixAppPath {
+		return Result{}, errors.New("streaming does not support prefixing app paths")
+	}
+	if cfg.converter.Fingerprint == converter.FingerprintPathNormalized {
+		return Result{}, errors.New("streaming does not support path-normalized fingerprints")
+	}
+
+	c := &cfg.converter
+	decoder := brakeman.NewDecoder(r)
+	encoder := codequality.NewEncoder(w)
+	var result Result
+	emit := func(violation codequality.Violation) error {
+		result.Violations++
+		result.Failed = result.Failed || cfg.fails(violation)
+		return encoder.Encode(violation)
+	}
+
+	for i := 0; ; i++ {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		warning, err := decoder.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return result, err
+		}
+
+		if skip, ok := c.Validate(i, warning); !ok {
+			result.Skipped = append(result.Skipped, skip)
+			continue
+		}
+		if violation, ok := c.Warning(warning); ok {
+			if err := emit(violation); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	for _, violation := range cfg.diagnostics(c, decoder.Report()) {
+		if err := emit(violation); err != nil {
+			return result, err
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return result, err
+	}
+
+	if cfg.strict && len(result.Skipped) > 0 {
+		return result, ErrSkipped
+	}
+	return result, nil
+}
+
+// diagnostics converts the Brakeman errors and obsolete ignore entries requested by cfg with c.
+func (cfg *config) diagnostics(c *converter.Converter, report *brakeman.Report) []codequality.Violation {
+	var violations []codequality.Violation
+	if cfg.includeErrors {
+		violations = append(violations, c.Errors(report.Errors)...)
+	}
+	if cfg.includeObsolete {
+		violations = append(violations, c.Obsolete(report.Obsolete, cfg.ignoreFile)...)
+	}
+	return violations
+}
+
+func (cfg *config) fails(violation codequality.Violation) bool {
+	return cfg.failOn != "" && converter.AtLeast(violation.Severity, cfg.failOn)
+}
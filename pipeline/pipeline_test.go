@@ -0,0 +1,272 @@
+package pipeline_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/brakeman"
+	"github.com/Omochice/brakeman-to-codequality/codequality"
+	"github.com/Omochice/brakeman-to-codequality/converter"
+	"github.com/Omochice/brakeman-to-codequality/pipeline"
+)
+
+const report = `{"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"},{"warning_type":"Redirect","message":"Possible unprotected redirect","file":"app/controllers/users_controller.rb","confidence":"Weak","fingerprint":"fp2"},{"warning_type":"Cross-Site Scripting","message":"Unescaped parameter value","file":"app/views/users/show.html.erb","line":3,"confidence":"Weak","fingerprint":"fp3"}]}`
+
+func TestConvert(t *testing.T) {
+	t.Run("writes CodeQuality JSON by default", func(t *testing.T) {
+		var buf bytes.Buffer
+		result, err := pipeline.Convert(context.Background(), strings.NewReader(report), &buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		violations, err := codequality.Parse(&buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(violations) != 2 {
+			t.Fatalf("got %d violations, want 2", len(violations))
+		}
+		if result.Violations != 2 {
+			t.Fatalf("got %d, want 2", result.Violations)
+		}
+		if len(result.Skipped) != 1 || result.Skipped[0].Index != 1 {
+			t.Fatalf("got %+v, want the second warning skipped", result.Skipped)
+		}
+		if result.Failed {
+			t.Fatal("expected Failed to be false without WithFailOn")
+		}
+	})
+
+	t.Run("writes the selected format", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := pipeline.Convert(context.Background(), strings.NewReader(report), &buf, pipeline.WithFormat(pipeline.FormatSARIF))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var log struct {
+			Version string `json:"version"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if log.Version != "2.1.0" {
+			t.Fatalf("got %q, want %q", log.Version, "2.1.0")
+		}
+	})
+
+	t.Run("reports a violation meeting the fail-on threshold", func(t *testing.T) {
+		var buf bytes.Buffer
+		result, err := pipeline.Convert(context.Background(), strings.NewReader(report), &buf, pipeline.WithFailOn("critical"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.Failed {
+			t.Fatal("expected Failed to be true")
+		}
+	})
+
+	t.Run("rejects an unknown fail-on severity", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := pipeline.Convert(context.Background(), strings.NewReader(report), &buf, pipeline.WithFailOn("Critical"))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("writes nothing and returns ErrSkipped in strict mode", func(t *testing.T) {
+		var buf bytes.Buffer
+		result, err := pipeline.Convert(context.Background(), strings.NewReader(report), &buf, pipeline.WithStrict())
+		if !errors.Is(err, pipeline.ErrSkipped) {
+			t.Fatalf("got %v, want %v", err, pipeline.ErrSkipped)
+		}
+		if len(result.Skipped) != 1 {
+			t.Fatalf("got %d skips, want 1", len(result.Skipped))
+		}
+		if buf.Len() != 0 {
+			t.Fatalf("expected no output, got %q", buf.String())
+		}
+	})
+
+	t.Run("streams the same output as the buffered conversion", func(t *testing.T) {
+		options := []pipeline.Option{
+			pipeline.WithIgnore(&brakeman.Ignore{IgnoredWarnings: []brakeman.IgnoredWarning{{Warning: brakeman.Warning{Fingerprint: "fp3"}, Note: "escaped"}}}, converter.IgnoreDowngrade),
+			pipeline.WithFailOn("critical"),
+		}
+
+		var buffered, streamed bytes.Buffer
+		want, err := pipeline.Convert(context.Background(), strings.NewReader(report), &buffered, options...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, err := pipeline.Convert(context.Background(), strings.NewReader(report), &streamed, append(options, pipeline.WithStream())...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if streamed.String() != buffered.String() {
+			t.Fatalf("got %q, want %q", streamed.String(), buffered.String())
+		}
+		if got.Violations != want.Violations || got.Failed != want.Failed || len(got.Skipped) != len(want.Skipped) {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("writes a summary alongside the output", func(t *testing.T) {
+		var buf, summary bytes.Buffer
+		_, err := pipeline.Convert(context.Background(), strings.NewReader(report), &buf, pipeline.WithSummary(&summary, false))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := codequality.Parse(&buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(summary.String(), "Total") {
+			t.Fatalf("expected %q to contain %q", summary.String(), "Total")
+		}
+	})
+
+	t.Run("rejects a summary alongside the text format", func(t *testing.T) {
+		var buf, summary bytes.Buffer
+		_, err := pipeline.Convert(context.Background(), strings.NewReader(report), &buf, pipeline.WithSummary(&summary, false), pipeline.WithFormat(pipeline.FormatText))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("rejects Brakeman errors for other formats", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := pipeline.Convert(context.Background(), strings.NewReader(report), &buf, pipeline.WithErrors(), pipeline.WithFormat(pipeline.FormatSARIF))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("rejects streaming other formats", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := pipeline.Convert(context.Background(), strings.NewReader(report), &buf, pipeline.WithStream(), pipeline.WithFormat(pipeline.FormatSARIF))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("returns the context error when canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var buf bytes.Buffer
+		_, err := pipeline.Convert(ctx, strings.NewReader(report), &buf)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("got %v, want %v", err, context.Canceled)
+		}
+	})
+
+	t.Run("returns error for invalid JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := pipeline.Convert(context.Background(), strings.NewReader(`{invalid`), &buf)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestConvertReports(t *testing.T) {
+	t.Run("lists baseline violations that are gone", func(t *testing.T) {
+		previous := `[{"description":"Old","check_name":"XSS","fingerprint":"gone","severity":"major","location":{"path":"app/views/old.erb","lines":{"begin":1}}},{"description":"Possible SQL injection","check_name":"SQL Injection","fingerprint":"fp1","severity":"critical","location":{"path":"app/models/user.rb","lines":{"begin":42}}}]`
+		baseline, err := pipeline.ParseBaseline(strings.NewReader(previous))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		parsed, err := brakeman.Parse(strings.NewReader(report))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var buf bytes.Buffer
+		result, err := pipeline.ConvertReports(context.Background(), []*brakeman.Report{parsed}, &buf, pipeline.WithBaseline(baseline))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.Violations != 1 {
+			t.Fatalf("got %d, want 1", result.Violations)
+		}
+		if len(result.Fixed) != 1 || result.Fixed[0].Fingerprint != "gone" {
+			t.Fatalf("got %+v, want the gone violation", result.Fixed)
+		}
+	})
+
+	t.Run("prefixes each report with its app path", func(t *testing.T) {
+		api, err := brakeman.Parse(strings.NewReader(`{"scan_info":{"app_path":"engines/api"},"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"}]}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		billing, err := brakeman.Parse(strings.NewReader(`{"scan_info":{"app_path":"engines/billing"},"warnings":[{"warning_type":"SQL Injection","message":"Possible SQL injection","file":"app/models/user.rb","line":42,"confidence":"High","fingerprint":"fp1"}]}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var buf bytes.Buffer
+		if _, err := pipeline.ConvertReports(context.Background(), []*brakeman.Report{api, billing}, &buf, pipeline.WithPrefixAppPath()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		violations, err := codequality.Parse(&buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(violations) != 2 {
+			t.Fatalf("got %d violations, want 2", len(violations))
+		}
+		if violations[0].Location.Path != "engines/api/app/models/user.rb" || violations[1].Location.Path != "engines/billing/app/models/user.rb" {
+			t.Fatalf("unexpected paths: %v and %v", violations[0].Location.Path, violations[1].Location.Path)
+		}
+	})
+
+	t.Run("returns error when prefixing a report without app path", func(t *testing.T) {
+		parsed, err := brakeman.Parse(strings.NewReader(report))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var buf bytes.Buffer
+		if _, err := pipeline.ConvertReports(context.Background(), []*brakeman.Report{parsed}, &buf, pipeline.WithPrefixAppPath()); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("does not list Brakeman errors still present as fixed", func(t *testing.T) {
+		input := `{"warnings":[],"errors":[{"error":"syntax error Could not parse app/views/users/edit.html.erb","location":"/usr/local/bundle/gems/ruby_parser-3.21.0/lib/ruby_parser_extras.rb:1218:in 'on_error'"}]}`
+		parsed, err := brakeman.Parse(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var previous bytes.Buffer
+		if _, err := pipeline.ConvertReports(context.Background(), []*brakeman.Report{parsed}, &previous, pipeline.WithErrors()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		baseline, err := pipeline.ParseBaseline(&previous)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var buf bytes.Buffer
+		result, err := pipeline.ConvertReports(context.Background(), []*brakeman.Report{parsed}, &buf, pipeline.WithErrors(), pipeline.WithBaseline(baseline))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Violations != 0 {
+			t.Fatalf("got %d, want 0", result.Violations)
+		}
+		if len(result.Fixed) != 0 {
+			t.Fatalf("got %+v, want nothing fixed", result.Fixed)
+		}
+	})
+}
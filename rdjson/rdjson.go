@@ -0,0 +1,69 @@
+package rdjson
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// DiagnosticResult is the root object of the reviewdog Diagnostic Format (rdjson).
+type DiagnosticResult struct {
+	Source      *Source      `json:"source,omitempty"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+type Source struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type Diagnostic struct {
+	Message  string   `json:"message"`
+	Location Location `json:"location"`
+	Severity string   `json:"severity,omitempty"`
+	Source   *Source  `json:"source,omitempty"`
+	Code     *Code    `json:"code,omitempty"`
+}
+
+type Location struct {
+	Path  string `json:"path"`
+	Range *Range `json:"range,omitempty"`
+}
+
+type Range struct {
+	Start Position  `json:"start"`
+	End   *Position `json:"end,omitempty"`
+}
+
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column,omitempty"`
+}
+
+type Code struct {
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+// Write encodes result as JSON into w.
+func Write(result *DiagnosticResult, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(result); err != nil {
+		return err
+	}
+	return nil
+}
+
+// WriteLines encodes the diagnostics of result into w as JSON Lines (rdjsonl),
+// one diagnostic per line. Diagnostics without a source get the source of result.
+func WriteLines(result *DiagnosticResult, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	for _, diagnostic := range result.Diagnostics {
+		if diagnostic.Source == nil {
+			diagnostic.Source = result.Source
+		}
+		if err := encoder.Encode(diagnostic); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package rdjson_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/rdjson"
+)
+
+var diagnostic = rdjson.Diagnostic{
+	Message: "Possible SQL injection",
+	Location: rdjson.Location{
+		Path:  "app/models/user.rb",
+		Range: &rdjson.Range{Start: rdjson.Position{Line: 42}, End: &rdjson.Position{Line: 42}},
+	},
+	Severity: "ERROR",
+	Code:     &rdjson.Code{Value: "SQL Injection", URL: "https://brakemanscanner.org/docs/warning_types/sql_injection/"},
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("writes a diagnostic result", func(t *testing.T) {
+		result := &rdjson.DiagnosticResult{
+			Source:      &rdjson.Source{Name: "brakeman"},
+			Diagnostics: []rdjson.Diagnostic{diagnostic},
+		}
+
+		var buf bytes.Buffer
+		if err := rdjson.Write(result, &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var decoded map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("failed to decode output as JSON: %v", err)
+		}
+		if decoded["source"].(map[string]any)["name"] != "brakeman" {
+			t.Fatalf("got %v, want %v", decoded["source"], "brakeman")
+		}
+		start := decoded["diagnostics"].([]any)[0].(map[string]any)["location"].(map[string]any)["range"].(map[string]any)["start"].(map[string]any)
+		if _, ok := start["column"]; ok {
+			t.Fatalf("expected column to be omitted, got %v", start["column"])
+		}
+	})
+}
+
+func TestWriteLines(t *testing.T) {
+	t.Run("writes one diagnostic per line with the result source", func(t *testing.T) {
+		result := &rdjson.DiagnosticResult{
+			Source:      &rdjson.Source{Name: "brakeman"},
+			Diagnostics: []rdjson.Diagnostic{diagnostic, diagnostic},
+		}
+
+		var buf bytes.Buffer
+		if err := rdjson.WriteLines(result, &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("got %d lines, want 2", len(lines))
+		}
+		var decoded rdjson.Diagnostic
+		if err := json.Unmarshal([]byte(lines[0]), &decoded); err != nil {
+			t.Fatalf("failed to decode line as JSON: %v", err)
+		}
+		if decoded.Message != diagnostic.Message {
+			t.Fatalf("got %q, want %q", decoded.Message, diagnostic.Message)
+		}
+		if decoded.Source == nil || decoded.Source.Name != "brakeman" {
+			t.Fatalf("got %+v, want the source of the result", decoded.Source)
+		}
+	})
+}
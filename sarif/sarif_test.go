@@ -0,0 +1,64 @@
+package sarif_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/sarif"
+)
+
+func TestWrite(t *testing.T) {
+	t.Run("writes SARIF log", func(t *testing.T) {
+		log := &sarif.Log{
+			Schema:  sarif.Schema,
+			Version: sarif.Version,
+			Runs: []sarif.Run{
+				{
+					Tool: sarif.Tool{Driver: sarif.Driver{Name: "Brakeman", Rules: []sarif.Rule{{ID: "BRAKE0000"}}}},
+					Results: []sarif.Result{
+						{
+							RuleID:  "BRAKE0000",
+							Level:   "error",
+							Message: sarif.Message{Text: "Possible SQL injection"},
+							Locations: []sarif.Location{
+								{
+									PhysicalLocation: sarif.PhysicalLocation{
+										ArtifactLocation: sarif.ArtifactLocation{URI: "app/models/user.rb"},
+										Region:           &sarif.Region{StartLine: 42},
+									},
+								},
+							},
+							PartialFingerprints: map[string]string{"brakeman/v1": "abc123"},
+						},
+					},
+				},
+			},
+		}
+
+		var buf bytes.Buffer
+		if err := sarif.Write(log, &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var decoded map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("failed to decode output as JSON: %v", err)
+		}
+		if decoded["version"] != "2.1.0" {
+			t.Fatalf("got %v, want %v", decoded["version"], "2.1.0")
+		}
+		if decoded["$schema"] != sarif.Schema {
+			t.Fatalf("got %v, want %v", decoded["$schema"], sarif.Schema)
+		}
+
+		result := decoded["runs"].([]any)[0].(map[string]any)["results"].([]any)[0].(map[string]any)
+		if result["ruleId"] != "BRAKE0000" {
+			t.Fatalf("got %v, want %v", result["ruleId"], "BRAKE0000")
+		}
+		region := result["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)["region"].(map[string]any)
+		if region["startLine"] != float64(42) {
+			t.Fatalf("got %v, want %v", region["startLine"], 42)
+		}
+	})
+}
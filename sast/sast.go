@@ -0,0 +1,71 @@
+package sast
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// SchemaVersion is the GitLab security report schema version the report conforms to.
+const SchemaVersion = "15.0.7"
+
+// TimeLayout is the timestamp format required for scan start and end times.
+const TimeLayout = "2006-01-02T15:04:05"
+
+type Report struct {
+	Version         string          `json:"version"`
+	Scan            Scan            `json:"scan"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+}
+
+type Scan struct {
+	Analyzer  Tool   `json:"analyzer"`
+	Scanner   Tool   `json:"scanner"`
+	Type      string `json:"type"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	Status    string `json:"status"`
+}
+
+type Tool struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	URL     string `json:"url,omitempty"`
+	Version string `json:"version"`
+	Vendor  Vendor `json:"vendor"`
+}
+
+type Vendor struct {
+	Name string `json:"name"`
+}
+
+type Vulnerability struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Severity    string       `json:"severity,omitempty"`
+	Identifiers []Identifier `json:"identifiers"`
+	Location    Location     `json:"location"`
+}
+
+type Identifier struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+type Location struct {
+	File      string `json:"file,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	Class     string `json:"class,omitempty"`
+	Method    string `json:"method,omitempty"`
+}
+
+// Write encodes report as JSON into w.
+func Write(report *Report, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	return nil
+}
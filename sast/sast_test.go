@@ -0,0 +1,53 @@
+package sast_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/sast"
+)
+
+func TestWrite(t *testing.T) {
+	t.Run("writes SAST report", func(t *testing.T) {
+		report := &sast.Report{
+			Version: sast.SchemaVersion,
+			Scan: sast.Scan{
+				Scanner: sast.Tool{ID: "brakeman", Name: "Brakeman", Version: "6.1.0", Vendor: sast.Vendor{Name: "Brakeman"}},
+				Type:    "sast",
+				Status:  "success",
+			},
+			Vulnerabilities: []sast.Vulnerability{
+				{
+					ID:          "abc123",
+					Name:        "SQL Injection",
+					Severity:    "High",
+					Identifiers: []sast.Identifier{{Type: "cwe", Name: "CWE-89", Value: "89"}},
+					Location:    sast.Location{File: "app/models/user.rb", StartLine: 42},
+				},
+			},
+		}
+
+		var buf bytes.Buffer
+		if err := sast.Write(report, &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var decoded map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("failed to decode output as JSON: %v", err)
+		}
+		if decoded["version"] != sast.SchemaVersion {
+			t.Fatalf("got %v, want %v", decoded["version"], sast.SchemaVersion)
+		}
+
+		vulnerability := decoded["vulnerabilities"].([]any)[0].(map[string]any)
+		location := vulnerability["location"].(map[string]any)
+		if location["start_line"] != float64(42) {
+			t.Fatalf("got %v, want %v", location["start_line"], 42)
+		}
+		if _, ok := location["class"]; ok {
+			t.Fatalf("expected class to be omitted, got %v", location["class"])
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package sonar
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Report is a SonarQube Generic Issue Data document.
+// Rules are read by SonarQube 10.3 and later; earlier versions only read the
+// engine, type and severity of each issue.
+type Report struct {
+	Rules  []Rule  `json:"rules"`
+	Issues []Issue `json:"issues"`
+}
+
+type Rule struct {
+	ID                 string   `json:"id"`
+	Name               string   `json:"name"`
+	Description        string   `json:"description"`
+	EngineID           string   `json:"engineId"`
+	CleanCodeAttribute string   `json:"cleanCodeAttribute"`
+	Type               string   `json:"type,omitempty"`
+	Severity           string   `json:"severity,omitempty"`
+	Impacts            []Impact `json:"impacts"`
+}
+
+type Impact struct {
+	SoftwareQuality string `json:"softwareQuality"`
+	Severity        string `json:"severity"`
+}
+
+type Issue struct {
+	EngineID        string   `json:"engineId,omitempty"`
+	RuleID          string   `json:"ruleId"`
+	Severity        string   `json:"severity,omitempty"`
+	Type            string   `json:"type,omitempty"`
+	PrimaryLocation Location `json:"primaryLocation"`
+}
+
+type Location struct {
+	Message   string     `json:"message"`
+	FilePath  string     `json:"filePath"`
+	TextRange *TextRange `json:"textRange,omitempty"`
+}
+
+type TextRange struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine,omitempty"`
+}
+
+// Write encodes report as JSON into w.
+func Write(report *Report, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package sonar_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/sonar"
+)
+
+func TestWrite(t *testing.T) {
+	t.Run("writes generic issue data", func(t *testing.T) {
+		report := &sonar.Report{
+			Rules: []sonar.Rule{
+				{
+					ID:                 "BRAKE0000",
+					Name:               "SQL Injection",
+					Description:        "SQL Injection",
+					EngineID:           "brakeman",
+					CleanCodeAttribute: "TRUSTWORTHY",
+					Type:               "VULNERABILITY",
+					Severity:           "CRITICAL",
+					Impacts:            []sonar.Impact{{SoftwareQuality: "SECURITY", Severity: "HIGH"}},
+				},
+			},
+			Issues: []sonar.Issue{
+				{
+					EngineID: "brakeman",
+					RuleID:   "BRAKE0000",
+					Severity: "CRITICAL",
+					Type:     "VULNERABILITY",
+					PrimaryLocation: sonar.Location{
+						Message:   "Possible SQL injection",
+						FilePath:  "app/models/user.rb",
+						TextRange: &sonar.TextRange{StartLine: 42},
+					},
+				},
+			},
+		}
+
+		var buf bytes.Buffer
+		if err := sonar.Write(report, &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var decoded map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("failed to decode output as JSON: %v", err)
+		}
+		issue := decoded["issues"].([]any)[0].(map[string]any)
+		textRange := issue["primaryLocation"].(map[string]any)["textRange"].(map[string]any)
+		if textRange["startLine"] != float64(42) {
+			t.Fatalf("got %v, want %v", textRange["startLine"], 42)
+		}
+		if _, ok := textRange["endLine"]; ok {
+			t.Fatalf("expected endLine to be omitted, got %v", textRange["endLine"])
+		}
+		rule := decoded["rules"].([]any)[0].(map[string]any)
+		if rule["cleanCodeAttribute"] != "TRUSTWORTHY" {
+			t.Fatalf("got %v, want %v", rule["cleanCodeAttribute"], "TRUSTWORTHY")
+		}
+	})
+}
@@ -0,0 +1,186 @@
+// Package text renders findings for people reading them in a terminal.
+package text
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Severities are the CodeQuality severities, most severe first.
+var Severities = []string{"blocker", "critical", "major", "minor", "info"}
+
+// Report is the list of findings to render, grouped by file.
+type Report struct {
+	Files []File
+}
+
+type File struct {
+	Path     string
+	Findings []Finding
+}
+
+type Finding struct {
+	Line     int
+	Severity string
+	Type     string
+	Message  string
+	Code     string
+}
+
+// Total counts the findings of one warning type by severity.
+type Total struct {
+	Type       string
+	BySeverity map[string]int
+	Count      int
+}
+
+// Totals counts the findings of report by warning type, in order of first appearance.
+func (r *Report) Totals() []Total {
+	var totals []Total
+	index := map[string]int{}
+	for _, file := range r.Files {
+		for _, finding := range file.Findings {
+			i, ok := index[finding.Type]
+			if !ok {
+				i = len(totals)
+				index[finding.Type] = i
+				totals = append(totals, Total{Type: finding.Type, BySeverity: map[string]int{}})
+			}
+			totals[i].BySeverity[finding.Severity]++
+			totals[i].Count++
+		}
+	}
+	return totals
+}
+
+var colors = map[string]string{
+	"blocker":  "\x1b[1;31m",
+	"critical": "\x1b[31m",
+	"major":    "\x1b[33m",
+	"minor":    "\x1b[36m",
+	"info":     "\x1b[2m",
+}
+
+const (
+	bold  = "\x1b[1m"
+	dim   = "\x1b[2m"
+	reset = "\x1b[0m"
+)
+
+// printer writes ANSI colored text when color is set.
+type printer struct {
+	w     io.Writer
+	color bool
+	err   error
+}
+
+func (p *printer) printf(format string, args ...any) {
+	if p.err == nil {
+		_, p.err = fmt.Fprintf(p.w, format, args...)
+	}
+}
+
+func (p *printer) paint(style, s string) string {
+	if !p.color || style == "" {
+		return s
+	}
+	return style + s + reset
+}
+
+// Write prints the findings of report grouped by file, followed by the totals.
+// Severities are colored with ANSI escape sequences when color is set.
+func Write(report *Report, w io.Writer, color bool) error {
+	p := &printer{w: w, color: color}
+
+	for _, file := range report.Files {
+		p.printf("%s\n", p.paint(bold, file.Path))
+		for _, finding := range file.Findings {
+			severity := fmt.Sprintf("%-8s", finding.Severity)
+			p.printf("  %5d  %s  %s: %s\n", finding.Line, p.paint(colors[finding.Severity], severity), finding.Type, finding.Message)
+			if finding.Code != "" {
+				for _, line := range strings.Split(finding.Code, "\n") {
+					p.printf("         %s\n", p.paint(dim, line))
+				}
+			}
+		}
+		p.printf("\n")
+	}
+	if p.err != nil {
+		return p.err
+	}
+
+	return WriteSummary(report, w, color)
+}
+
+// WriteSummary prints a table of the findings of report by warning type and severity.
+func WriteSummary(report *Report, w io.Writer, color bool) error {
+	p := &printer{w: w, color: color}
+
+	totals := report.Totals()
+	if len(totals) == 0 {
+		p.printf("No warnings found.\n")
+		return p.err
+	}
+
+	header := append([]string{"Warning type"}, Severities...)
+	header = append(header, "total")
+	rows := make([][]string, 0, len(totals)+1)
+	sum := Total{Type: "Total", BySeverity: map[string]int{}}
+	for _, total := range totals {
+		rows = append(rows, row(total))
+		for severity, count := range total.BySeverity {
+			sum.BySeverity[severity] += count
+		}
+		sum.Count += total.Count
+	}
+	rows = append(rows, row(sum))
+
+	widths := make([]int, len(header))
+	for i, cell := range header {
+		widths[i] = utf8.RuneCountInString(cell)
+	}
+	for _, cells := range rows {
+		for i, cell := range cells {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	cells := make([]string, len(header))
+	for i, cell := range header {
+		style := bold
+		if i > 0 && i <= len(Severities) {
+			style = colors[cell]
+		}
+		cells[i] = p.paint(style, pad(cell, widths[i], i > 0))
+	}
+	p.printf("%s\n", strings.Join(cells, "  "))
+	for r, row := range rows {
+		for i, cell := range row {
+			cells[i] = pad(cell, widths[i], i > 0)
+			if r == len(rows)-1 {
+				cells[i] = p.paint(bold, cells[i])
+			}
+		}
+		p.printf("%s\n", strings.Join(cells, "  "))
+	}
+	return p.err
+}
+
+func row(total Total) []string {
+	cells := []string{total.Type}
+	for _, severity := range Severities {
+		cells = append(cells, fmt.Sprint(total.BySeverity[severity]))
+	}
+	return append(cells, fmt.Sprint(total.Count))
+}
+
+// pad fills s with spaces up to width, on the left when right is set.
+func pad(s string, width int, right bool) string {
+	padding := strings.Repeat(" ", width-utf8.RuneCountInString(s))
+	if right {
+		return padding + s
+	}
+	return s + padding
+}
@@ -0,0 +1,88 @@
+package text_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Omochice/brakeman-to-codequality/text"
+)
+
+var report = &text.Report{
+	Files: []text.File{
+		{
+			Path: "app/models/user.rb",
+			Findings: []text.Finding{
+				{Line: 42, Severity: "critical", Type: "SQL Injection", Message: "Possible SQL injection", Code: `User.where("id = #{params[:id]}")`},
+				{Line: 50, Severity: "minor", Type: "SQL Injection", Message: "Possible SQL injection"},
+			},
+		},
+		{
+			Path: "app/controllers/users_controller.rb",
+			Findings: []text.Finding{
+				{Line: 7, Severity: "major", Type: "Redirect", Message: "Possible unprotected redirect"},
+			},
+		},
+	},
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("prints findings grouped by file followed by the totals", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := text.Write(report, &buf, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := `app/models/user.rb
+     42  critical  SQL Injection: Possible SQL injection
+         User.where("id = #{params[:id]}")
+     50  minor     SQL Injection: Possible SQL injection
+
+app/controllers/users_controller.rb
+      7  major     Redirect: Possible unprotected redirect
+
+Warning type   blocker  critical  major  minor  info  total
+SQL Injection        0         1      0      1     0      2
+Redirect             0         0      1      0     0      1
+Total                0         1      1      1     0      3
+`
+		if buf.String() != want {
+			t.Fatalf("got:\n%s\nwant:\n%s", buf.String(), want)
+		}
+	})
+
+	t.Run("colors severities when requested", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := text.Write(report, &buf, true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !strings.Contains(buf.String(), "\x1b[31mcritical\x1b[0m") {
+			t.Fatalf("expected %q to contain a red critical", buf.String())
+		}
+	})
+
+	t.Run("writes no escape sequences without color", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := text.Write(report, &buf, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if strings.Contains(buf.String(), "\x1b[") {
+			t.Fatalf("expected no escape sequences, got %q", buf.String())
+		}
+	})
+}
+
+func TestWriteSummary(t *testing.T) {
+	t.Run("reports when there are no findings", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := text.WriteSummary(&text.Report{}, &buf, true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if buf.String() != "No warnings found.\n" {
+			t.Fatalf("got %q, want %q", buf.String(), "No warnings found.\n")
+		}
+	})
+}